@@ -0,0 +1,53 @@
+package parsley
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// The benchmarks below only use API that has existed since before binding plans were introduced,
+// so this file can be copied into an earlier commit to compare against it.
+
+type _BenchmarkArgs struct {
+	Name    string
+	Count   int
+	Ratio   float64
+	Enabled bool   `default:"true"`
+	Reason  string `default:"No reason provided."`
+}
+
+func _BenchmarkParser(b *testing.B) *Parser {
+	parser := New("!")
+	err := parser.NewCommand("bench", "", func(message *discordgo.MessageCreate, args _BenchmarkArgs) {})
+	if err != nil {
+		b.Fatalf("adding command returned unexpected error: %s", err)
+	}
+	return parser
+}
+
+func BenchmarkRunCommandPositional(b *testing.B) {
+	parser := _BenchmarkParser(b)
+	message := &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!bench test 10 0.5 false reason"}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := parser.RunCommand(message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRunCommandDefaultsAndKwargs(b *testing.B) {
+	parser := _BenchmarkParser(b)
+	message := &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!bench test 10 Ratio=0.5"}}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := parser.RunCommand(message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
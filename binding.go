@@ -0,0 +1,410 @@
+package parsley
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// _ArgumentBinding represents the precompiled details of an individual command argument.
+type _ArgumentBinding struct {
+	index        int
+	name         string
+	aliases      []string
+	flag         string
+	short        string
+	boolean      bool
+	typeName     string
+	description  string
+	convert      _Converter
+	contextual   bool
+	variadic     bool
+	rest         bool
+	rawDefault   string
+	defaults     []string
+	defaultValue reflect.Value
+	hasDefault   bool
+}
+
+// _BindingPlan represents the precompiled steps required to bind a command's arguments to its argument struct.
+type _BindingPlan struct {
+	argsType     reflect.Type
+	arguments    []_ArgumentBinding
+	kwargs       map[string]int
+	foldedKwargs map[string]int
+	flags        map[string]int
+	shorts       map[string]int
+}
+
+// _BindingOptions represents the parser-wide settings that affect how arguments are bound.
+type _BindingOptions struct {
+	caseInsensitiveKeywords bool
+	flexibleOrdering        bool
+}
+
+// _NewBindingPlan inspects an argument struct and builds the plan used to bind arguments to it.
+// Fields which are unexported, of an unsupported type or have an unparsable default value are rejected.
+//
+// Slice fields consume all remaining positional arguments, and string fields tagged with rest:"true" capture the
+// remainder of the message verbatim, without it being tokenized. Both must be the last field of the struct.
+//
+// Arguments are named after their field unless overridden by a name tag, and can be given additional names using a
+// comma-separated aliases tag. Each name can also be provided as a flag in kebab-case, along with an optional single character
+// short flag from a short tag. Boolean fields default to false, so they can be provided as flags without a value or omitted.
+func _NewBindingPlan(argsType reflect.Type, converters map[reflect.Type]ConverterFunc) (*_BindingPlan, error) {
+	plan := &_BindingPlan{
+		argsType:     argsType,
+		arguments:    make([]_ArgumentBinding, 0, argsType.NumField()),
+		kwargs:       make(map[string]int, argsType.NumField()),
+		foldedKwargs: make(map[string]int, argsType.NumField()),
+		flags:        make(map[string]int, argsType.NumField()),
+		shorts:       make(map[string]int),
+	}
+
+	for index := 0; index < argsType.NumField(); index++ {
+		field := argsType.Field(index)
+		if !field.IsExported() {
+			return nil, &UnexportedArgumentError{field.Name, field.Type}
+		}
+
+		isLast := index == argsType.NumField()-1
+
+		convert, contextual := _ResolveConverter(field.Type, converters)
+		variadic := false
+		if convert == nil && field.Type.Kind() == reflect.Slice {
+			convert, contextual = _ResolveConverter(field.Type.Elem(), converters)
+			variadic = true
+		}
+		if convert == nil {
+			return nil, &UnsupportedArgumentTypeError{field.Name, field.Type}
+		}
+
+		rest := field.Tag.Get("rest") == "true"
+		if rest && field.Type.Kind() != reflect.String {
+			return nil, fmt.Errorf("argument %s: %w", field.Name, ErrRestArgumentNotString)
+		}
+		if (variadic || rest) && !isLast {
+			return nil, fmt.Errorf("argument %s: %w", field.Name, ErrGreedyArgumentNotLast)
+		}
+
+		description, hasDescription := field.Tag.Lookup("description")
+		if !hasDescription {
+			description = "No description provided."
+		}
+
+		name, hasName := field.Tag.Lookup("name")
+		if !hasName {
+			name = field.Name
+		}
+		var aliases []string
+		if aliasesTag, hasAliases := field.Tag.Lookup("aliases"); hasAliases {
+			aliases = strings.Split(aliasesTag, ",")
+			for aliasIndex, alias := range aliases {
+				aliases[aliasIndex] = strings.TrimSpace(alias)
+			}
+		}
+
+		// Names are registered as they are validated, so that an argument's aliases cannot conflict with each other either.
+		for _, argName := range append([]string{name}, aliases...) {
+			if argName == "" || strings.HasPrefix(argName, "-") || strings.ContainsRune(argName, '=') || strings.IndexFunc(argName, unicode.IsSpace) != -1 {
+				return nil, fmt.Errorf("argument %s: %q: %w", field.Name, argName, ErrInvalidArgumentName)
+			}
+			if _, found := plan.foldedKwargs[strings.ToLower(argName)]; found {
+				return nil, fmt.Errorf("argument %s: %s: %w", field.Name, argName, ErrArgumentNameConflict)
+			}
+			flag := _KebabCase(argName)
+			if _, found := plan.flags[flag]; found {
+				return nil, fmt.Errorf("argument %s: --%s: %w", field.Name, flag, ErrFlagNameConflict)
+			}
+
+			plan.kwargs[argName] = len(plan.arguments)
+			plan.foldedKwargs[strings.ToLower(argName)] = len(plan.arguments)
+			plan.flags[flag] = len(plan.arguments)
+		}
+
+		short := field.Tag.Get("short")
+		if short != "" {
+			if utf8.RuneCountInString(short) != 1 || short == "-" || short == "=" {
+				return nil, fmt.Errorf("argument %s: %w", field.Name, ErrInvalidFlagName)
+			}
+			if _, found := plan.shorts[short]; found {
+				return nil, fmt.Errorf("argument %s: -%s: %w", field.Name, short, ErrFlagNameConflict)
+			}
+			plan.shorts[short] = len(plan.arguments)
+		}
+
+		binding := _ArgumentBinding{
+			index:       index,
+			name:        name,
+			aliases:     aliases,
+			flag:        _KebabCase(name),
+			short:       short,
+			boolean:     !variadic && field.Type.Kind() == reflect.Bool,
+			typeName:    _TypeName(field.Type),
+			description: description,
+			convert:     convert,
+			contextual:  contextual,
+			variadic:    variadic,
+			rest:        rest,
+		}
+
+		binding.rawDefault, binding.hasDefault = field.Tag.Lookup("default")
+		if binding.boolean && !binding.hasDefault {
+			binding.rawDefault, binding.hasDefault = "false", true
+		}
+		if binding.hasDefault {
+			binding.defaults = []string{binding.rawDefault}
+			if variadic {
+				defaultTokens, err := DiscordTokenizer{}.Tokenize(binding.rawDefault)
+				if err != nil {
+					return nil, &InvalidDefaultValueError{field.Name, field.Type, binding.rawDefault, err}
+				}
+				binding.defaults = make([]string, len(defaultTokens))
+				for tokenIndex, token := range defaultTokens {
+					binding.defaults[tokenIndex] = token.Value
+				}
+			}
+		}
+
+		// Defaults for contextual converters can only be converted once a message is being handled.
+		// Defaults that reference memory, such as slices and pointers, are converted again for each message,
+		// so that handlers modifying their arguments cannot change the default used by later messages.
+		if binding.hasDefault && !binding.contextual {
+			defaultValue := reflect.New(field.Type).Elem()
+			if argErr := binding.set(nil, len(plan.arguments)+1, binding.defaults, defaultValue); argErr != nil {
+				return nil, &InvalidDefaultValueError{field.Name, field.Type, binding.rawDefault, argErr.Err}
+			}
+			if _IsSelfContained(field.Type) {
+				binding.defaultValue = defaultValue
+			}
+		}
+
+		plan.arguments = append(plan.arguments, binding)
+	}
+
+	return plan, nil
+}
+
+// bind parses the argument tokens read from the provided stream and returns a populated instance of the plan's argument struct.
+// Rest arguments capture the remainder of the message from the stream without it being tokenized.
+func (plan *_BindingPlan) bind(ctx *Context, tokens *_TokenStream, options _BindingOptions) (reflect.Value, error) {
+	argsValue := reflect.New(plan.argsType).Elem()
+
+	named := make([][]string, len(plan.arguments))
+	positional := make([][]string, len(plan.arguments))
+	rest, hasRest := "", false
+
+	// next is the position of the next argument able to receive positional values.
+	next := 0
+	parsingKwargs := false
+	flagsTerminated := false
+	for {
+		// Positional values skip arguments already provided by name, while variadic arguments receive every remaining value.
+		for next < len(plan.arguments) && (named[next] != nil || (positional[next] != nil && !plan.arguments[next].variadic)) {
+			next++
+		}
+		restNext := next < len(plan.arguments) && plan.arguments[next].rest
+
+		// Text that cannot be tokenized, such as an unclosed quote, can still be captured by a rest argument.
+		token, ok, err := tokens.peek(0)
+		if err != nil && !restNext {
+			return reflect.Value{}, err
+		}
+		if err == nil && !ok {
+			break
+		}
+
+		// Once the next positional argument is a rest argument, its remainder is no longer checked for flags, so that text such as --> can be captured.
+		if err == nil && !flagsTerminated && !(restNext && strings.HasPrefix(token.Value, "-") && token.Value != "--") {
+			if token.Value == "--" {
+				flagsTerminated = true
+				parsingKwargs = false
+				tokens.advance(1)
+				continue
+			}
+
+			position, value, consumed, err := plan.matchNamed(tokens, options)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if consumed != 0 {
+				binding := plan.arguments[position]
+				if positional[position] != nil {
+					return reflect.Value{}, &ArgumentConflictError{Argument: binding.name, Err: ErrArgumentPositionalAndNamed}
+				}
+				if named[position] != nil && !binding.variadic {
+					return reflect.Value{}, &ArgumentConflictError{Argument: binding.name, Err: ErrDuplicateArgument}
+				}
+				named[position] = append(named[position], value)
+				parsingKwargs = true
+				tokens.advance(consumed)
+				continue
+			}
+		}
+		if parsingKwargs && !options.flexibleOrdering {
+			return reflect.Value{}, ErrKwargsMustBeAtEnd
+		}
+
+		if next == len(plan.arguments) {
+			tokens.advance(1)
+			continue
+		}
+		if restNext {
+			rest, hasRest = tokens.remainder(), true
+			break
+		}
+		positional[next] = append(positional[next], token.Value)
+		tokens.advance(1)
+	}
+
+	missing := make([]string, 0)
+	for position, binding := range plan.arguments {
+		field := argsValue.Field(binding.index)
+
+		var values []string
+		if named[position] != nil {
+			values = named[position]
+		} else if binding.rest && hasRest {
+			values = []string{rest}
+		} else if positional[position] != nil {
+			values = positional[position]
+		} else if binding.defaultValue.IsValid() {
+			field.Set(binding.defaultValue)
+			continue
+		} else if binding.hasDefault {
+			values = binding.defaults
+		} else {
+			missing = append(missing, binding.name)
+			continue
+		}
+
+		if argErr := binding.set(ctx, position+1, values, field); argErr != nil {
+			return reflect.Value{}, argErr
+		}
+	}
+
+	if len(missing) != 0 {
+		return reflect.Value{}, &MissingArgumentsError{Arguments: missing}
+	}
+
+	return argsValue, nil
+}
+
+// matchNamed determines whether the first of the provided tokens is a named argument, provided as Name=value, --name value,
+// --name=value, -n value or -n=value. Boolean flags provided without a value are set to true.
+// Keywords are matched regardless of case if the parser has case-insensitive keywords enabled.
+// It returns the position of the argument, its value and the number of tokens consumed, which is zero for positional arguments.
+func (plan *_BindingPlan) matchNamed(tokens *_TokenStream, options _BindingOptions) (int, string, int, error) {
+	token, _, _ := tokens.peek(0)
+	val := token.Value
+
+	var flag, name, value string
+	var hasValue bool
+	var position int
+	var found bool
+	switch {
+	case strings.HasPrefix(val, "--"):
+		name, value, hasValue = strings.Cut(val[2:], "=")
+		flag = "--" + name
+		if position, found = plan.flags[name]; !found {
+			return 0, "", 0, &FlagError{Flag: flag, Err: ErrUnknownFlag}
+		}
+	case strings.HasPrefix(val, "-") && len(val) > 1:
+		name, value, hasValue = strings.Cut(val[1:], "=")
+		flag = "-" + name
+		// Unknown short flags are treated as positional arguments, so that negative numbers can be provided.
+		if position, found = plan.shorts[name]; !found {
+			return 0, "", 0, nil
+		}
+	default:
+		name, value, hasValue = strings.Cut(val, "=")
+		if !hasValue {
+			return 0, "", 0, nil
+		}
+		position, found = plan.kwargs[name]
+		if !found && options.caseInsensitiveKeywords {
+			position, found = plan.foldedKwargs[strings.ToLower(name)]
+		}
+		if !found {
+			return 0, "", 0, nil
+		}
+		return position, value, 1, nil
+	}
+
+	if hasValue {
+		return position, value, 1, nil
+	}
+	if plan.arguments[position].boolean {
+		return position, "true", 1, nil
+	}
+	next, ok, err := tokens.peek(1)
+	if err != nil {
+		return 0, "", 0, err
+	}
+	if !ok {
+		return 0, "", 0, &FlagError{Flag: flag, Err: ErrFlagMissingValue}
+	}
+	return position, next.Value, 2, nil
+}
+
+// _IsSelfContained returns whether values of the provided type are entirely copied when assigned, rather than referencing shared memory.
+func _IsSelfContained(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Pointer, reflect.Interface, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return false
+	case reflect.Array:
+		return _IsSelfContained(valueType.Elem())
+	case reflect.Struct:
+		for index := 0; index < valueType.NumField(); index++ {
+			if !_IsSelfContained(valueType.Field(index).Type) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// _KebabCase converts a Go identifier, such as UserID, into kebab-case, such as user-id.
+func _KebabCase(name string) string {
+	runes := []rune(name)
+
+	var builder strings.Builder
+	for index, char := range runes {
+		if char == '_' {
+			builder.WriteRune('-')
+			continue
+		}
+		if unicode.IsUpper(char) && index > 0 && runes[index-1] != '_' {
+			previousLower := unicode.IsLower(runes[index-1]) || unicode.IsDigit(runes[index-1])
+			nextLower := index+1 < len(runes) && unicode.IsLower(runes[index+1])
+			if previousLower || (unicode.IsUpper(runes[index-1]) && nextLower) {
+				builder.WriteRune('-')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(char))
+	}
+	return builder.String()
+}
+
+// set converts the provided values and stores the result in the provided field.
+// Variadic arguments receive every value, while other arguments receive the last value provided.
+func (binding *_ArgumentBinding) set(ctx *Context, position int, values []string, field reflect.Value) *ArgumentError {
+	if !binding.variadic {
+		value := values[len(values)-1]
+		if err := binding.convert(ctx, value, field); err != nil {
+			return &ArgumentError{Argument: binding.name, Position: position, Value: value, Type: binding.typeName, Err: err}
+		}
+		return nil
+	}
+
+	slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+	for index, value := range values {
+		if err := binding.convert(ctx, value, slice.Index(index)); err != nil {
+			return &ArgumentError{Argument: binding.name, Position: position, Value: value, Type: _TypeName(field.Type().Elem()), Err: err}
+		}
+	}
+	field.Set(slice)
+	return nil
+}
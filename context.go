@@ -0,0 +1,26 @@
+package parsley
+
+import (
+	"context"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Context contains the details of an individual command invocation.
+//
+// Context implements context.Context, and is cancelled once the command's handler returns.
+type Context struct {
+	context.Context
+
+	// Session is the session the message was received on. It is nil when the command was run through RunCommand.
+	Session *discordgo.Session
+	// Message is the message that invoked the command.
+	Message *discordgo.MessageCreate
+	// Prefix is the prefix the message started with, which is a mention of the bot if the parser's mention prefix was used.
+	Prefix string
+	// Command is the resolved name of the command being run.
+	Command string
+	// Arguments contains the raw argument tokens provided to the command, excluding the command name.
+	// The remainder of the message captured by a rest argument is included as a single argument. It is populated once the arguments have been parsed.
+	Arguments []string
+}
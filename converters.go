@@ -0,0 +1,141 @@
+package parsley
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+var _TextUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// ConverterFunc converts a raw argument value into a value of a custom argument type.
+// The returned value must be assignable to the type the converter was registered for.
+type ConverterFunc func(ctx *Context, raw string) (interface{}, error)
+
+// _Converter parses a raw argument value and stores the result in the provided field.
+// The provided context is nil when converting default values at registration time.
+type _Converter func(ctx *Context, value string, field reflect.Value) error
+
+// RegisterConverter registers a converter used to parse arguments of the provided type.
+// Registered converters take priority over the built-in argument types, and must be registered before any commands using them.
+//
+// Default values for arguments using a registered converter are converted when a command is run, rather than when it is registered.
+func (parser *Parser) RegisterConverter(argType reflect.Type, converter ConverterFunc) {
+	parser.converters[argType] = converter
+}
+
+// _ResolveConverter returns the converter for the provided type, and whether that converter requires a command context.
+// Registered converters are preferred, followed by encoding.TextUnmarshaler implementations and then the built-in kinds.
+func _ResolveConverter(fieldType reflect.Type, converters map[reflect.Type]ConverterFunc) (_Converter, bool) {
+	if converter, ok := converters[fieldType]; ok {
+		return _CustomConverter(fieldType, converter), true
+	}
+	if converter := _TextUnmarshalerConverter(fieldType); converter != nil {
+		return converter, false
+	}
+	return _KindConverter(fieldType), false
+}
+
+// _CustomConverter adapts a registered ConverterFunc into a _Converter for the provided type.
+func _CustomConverter(fieldType reflect.Type, converter ConverterFunc) _Converter {
+	return func(ctx *Context, value string, field reflect.Value) error {
+		result, err := converter(ctx, value)
+		if err != nil {
+			return err
+		}
+		if result == nil {
+			field.Set(reflect.Zero(fieldType))
+			return nil
+		}
+
+		resultValue := reflect.ValueOf(result)
+		if !resultValue.Type().AssignableTo(fieldType) {
+			return fmt.Errorf("%w: expected %s, got %s", ErrConverterInvalidResultType, fieldType, resultValue.Type())
+		}
+		field.Set(resultValue)
+		return nil
+	}
+}
+
+// _TextUnmarshalerConverter returns a converter for types implementing encoding.TextUnmarshaler, or nil if the type does not.
+func _TextUnmarshalerConverter(fieldType reflect.Type) _Converter {
+	if fieldType.Kind() == reflect.Ptr && fieldType.Implements(_TextUnmarshalerType) {
+		return func(ctx *Context, value string, field reflect.Value) error {
+			result := reflect.New(fieldType.Elem())
+			if err := result.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+				return err
+			}
+			field.Set(result)
+			return nil
+		}
+	}
+	if reflect.PtrTo(fieldType).Implements(_TextUnmarshalerType) {
+		return func(ctx *Context, value string, field reflect.Value) error {
+			return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+		}
+	}
+	return nil
+}
+
+// _KindConverter returns the converter for the kind of the provided type, or nil if the kind is not supported.
+func _KindConverter(fieldType reflect.Type) _Converter {
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return func(ctx *Context, value string, field reflect.Value) error {
+			boolVal, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			field.SetBool(boolVal)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bitSize := fieldType.Bits()
+		return func(ctx *Context, value string, field reflect.Value) error {
+			intVal, err := strconv.ParseInt(value, 10, bitSize)
+			if err != nil {
+				return err
+			}
+			field.SetInt(intVal)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		bitSize := fieldType.Bits()
+		return func(ctx *Context, value string, field reflect.Value) error {
+			uintVal, err := strconv.ParseUint(value, 10, bitSize)
+			if err != nil {
+				return err
+			}
+			field.SetUint(uintVal)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		bitSize := fieldType.Bits()
+		return func(ctx *Context, value string, field reflect.Value) error {
+			floatVal, err := strconv.ParseFloat(value, bitSize)
+			if err != nil {
+				return err
+			}
+			field.SetFloat(floatVal)
+			return nil
+		}
+	case reflect.String:
+		return func(ctx *Context, value string, field reflect.Value) error {
+			field.SetString(value)
+			return nil
+		}
+	}
+	return nil
+}
+
+// _TypeName returns a human-readable name for the provided argument type.
+func _TypeName(argType reflect.Type) string {
+	for argType.Kind() == reflect.Ptr {
+		argType = argType.Elem()
+	}
+	if argType.Name() != "" {
+		return argType.Name()
+	}
+	return argType.String()
+}
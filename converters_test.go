@@ -0,0 +1,238 @@
+package parsley
+
+import (
+	"errors"
+	"math/big"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+type _UpperString string
+
+func _UpperStringConverter(ctx *Context, raw string) (interface{}, error) {
+	return _UpperString(strings.ToUpper(raw)), nil
+}
+
+func TestRunCommandWithRegisteredConverter(t *testing.T) {
+	parser := New(".")
+	parser.RegisterConverter(reflect.TypeOf(_UpperString("")), _UpperStringConverter)
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Arg _UpperString
+	}) {
+		if args.Arg != "ABC" {
+			t.Errorf("handler was not passed correct value for converted arg")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test abc"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithRegisteredConverterOverridingBuiltInType(t *testing.T) {
+	parser := New(".")
+	parser.RegisterConverter(reflect.TypeOf(0), func(ctx *Context, raw string) (interface{}, error) {
+		return len(raw), nil
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Arg int
+	}) {
+		if args.Arg != 3 {
+			t.Errorf("handler was not passed correct value for converted arg")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test abc"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithRegisteredConverterReceivingContext(t *testing.T) {
+	parser := New(".")
+	message := &discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test abc"}}
+	parser.RegisterConverter(reflect.TypeOf(_UpperString("")), func(ctx *Context, raw string) (interface{}, error) {
+		if ctx.Message != message || ctx.Command != "test" {
+			t.Errorf("converter was not passed correct context")
+		}
+		return _UpperString(raw), nil
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Arg _UpperString
+	}) {
+	})
+
+	err := parser.RunCommand(message)
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithRegisteredConverterReturningError(t *testing.T) {
+	converterErr := errors.New("conversion failed")
+	parser := New(".")
+	parser.RegisterConverter(reflect.TypeOf(_UpperString("")), func(ctx *Context, raw string) (interface{}, error) {
+		return nil, converterErr
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Arg _UpperString
+	}) {
+		t.Errorf("handler was called despite converter error")
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test abc"}})
+	if !errors.Is(err, converterErr) {
+		t.Errorf("running command did not return converter error")
+	}
+}
+
+func TestRunCommandWithRegisteredConverterReturningIncorrectType(t *testing.T) {
+	parser := New(".")
+	parser.RegisterConverter(reflect.TypeOf(_UpperString("")), func(ctx *Context, raw string) (interface{}, error) {
+		return raw, nil
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Arg _UpperString
+	}) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test abc"}})
+	if !errors.Is(err, ErrConverterInvalidResultType) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestRunCommandWithRegisteredConverterDefault(t *testing.T) {
+	parser := New(".")
+	parser.RegisterConverter(reflect.TypeOf(_UpperString("")), _UpperStringConverter)
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Arg _UpperString `default:"default"`
+	}) {
+		if args.Arg != "DEFAULT" {
+			t.Errorf("handler was not passed correct value for converted default")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithTextUnmarshalerArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		IP  net.IP
+		Int *big.Int
+	}) {
+		if !args.IP.Equal(net.IPv4(127, 0, 0, 1)) {
+			t.Errorf("handler was not passed correct value for text unmarshaler value arg")
+		}
+		if args.Int.String() != "123456789012345678901234567890" {
+			t.Errorf("handler was not passed correct value for text unmarshaler pointer arg")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test 127.0.0.1 123456789012345678901234567890"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithInvalidTextUnmarshalerArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		IP net.IP
+	}) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test ABC"}})
+	var parseErr *net.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestNewCommandWithInvalidTextUnmarshalerDefault(t *testing.T) {
+	parser := New(".")
+
+	err := parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		IP net.IP `default:"ABC"`
+	}) {
+	})
+	var defaultErr *InvalidDefaultValueError
+	if !errors.As(err, &defaultErr) {
+		t.Errorf("parser did not return correct error")
+	}
+}
+
+func TestGetCommandWithConvertedArgumentTypes(t *testing.T) {
+	parser := New(".")
+	parser.RegisterConverter(reflect.TypeOf(time.Duration(0)), func(ctx *Context, raw string) (interface{}, error) {
+		return time.ParseDuration(raw)
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Duration time.Duration
+		Int      *big.Int
+	}) {
+	})
+
+	command, err := parser.GetCommand("test")
+	if err != nil {
+		t.Errorf("got unexpected error")
+	}
+
+	if diff := deep.Equal(command.Arguments, []ArgumentDetails{
+		{
+			Name:        "Duration",
+			Type:        "Duration",
+			Description: "No description provided.",
+			Required:    true,
+			Flag:        "duration",
+		},
+		{
+			Name:        "Int",
+			Type:        "Int",
+			Description: "No description provided.",
+			Required:    true,
+			Flag:        "int",
+		},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithModifiedDefaults(t *testing.T) {
+	parser := New(".")
+	runs := 0
+	err := parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Int    *big.Int `default:"5"`
+		Values []int    `default:"1 2"`
+	}) {
+		runs++
+		if diff := deep.Equal(args.Values, []int{1, 2}); diff != nil {
+			t.Errorf("run %d was passed modified slice default: %v", runs, diff)
+		}
+		if args.Int.Cmp(big.NewInt(5)) != 0 {
+			t.Errorf("run %d was passed modified pointer default %s", runs, args.Int)
+		}
+		args.Values[0] = 99
+		args.Int.SetInt64(42)
+	})
+	if err != nil {
+		t.Fatalf("adding command returned unexpected error: %s", err)
+	}
+
+	for index := 0; index < 2; index++ {
+		if err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}}); err != nil {
+			t.Errorf("running command returned unexpected error: %s", err)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package parsley
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// CooldownBucket determines which uses of a command count towards the same cooldown.
+type CooldownBucket int
+
+const (
+	// BucketUser tracks uses separately for each user.
+	BucketUser CooldownBucket = iota
+	// BucketChannel tracks uses separately for each channel.
+	BucketChannel
+	// BucketGuild tracks uses separately for each guild. Uses in direct messages are tracked separately for each channel.
+	BucketGuild
+	// BucketGlobal tracks all uses of the command together.
+	BucketGlobal
+)
+
+// _Cooldown represents the cooldown configured for an individual command.
+type _Cooldown struct {
+	uses   int
+	per    time.Duration
+	bucket CooldownBucket
+}
+
+// CooldownStore tracks uses of commands in order to enforce their cooldowns.
+type CooldownStore interface {
+	// Take records a use within the bucket with the provided key, if fewer than the provided number of uses
+	// have been recorded within the provided duration. If the use cannot be recorded, Take returns how long
+	// remains until it can be. Otherwise, it returns zero.
+	Take(ctx context.Context, key string, uses int, per time.Duration) (time.Duration, error)
+}
+
+// MemoryCooldownStore is a CooldownStore that tracks uses in memory. It is safe for concurrent use.
+type MemoryCooldownStore struct {
+	mutex     sync.Mutex
+	buckets   map[string][]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryCooldownStore creates a new, empty MemoryCooldownStore.
+func NewMemoryCooldownStore() *MemoryCooldownStore {
+	return &MemoryCooldownStore{buckets: make(map[string][]time.Time), now: time.Now}
+}
+
+// Take records a use within the bucket with the provided key, using a sliding window of the provided duration.
+func (store *MemoryCooldownStore) Take(ctx context.Context, key string, uses int, per time.Duration) (time.Duration, error) {
+	if uses <= 0 || per <= 0 {
+		return 0, ErrInvalidCooldown
+	}
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	now := store.now()
+	if now.Sub(store.lastSweep) >= time.Minute {
+		store.sweep(now)
+	}
+
+	bucket := _ExpireUses(store.buckets[key], now)
+	if len(bucket) >= uses {
+		store.buckets[key] = bucket
+		return bucket[len(bucket)-uses].Sub(now), nil
+	}
+
+	store.buckets[key] = append(bucket, now.Add(per))
+	return 0, nil
+}
+
+// sweep removes every bucket that no longer contains any unexpired uses.
+func (store *MemoryCooldownStore) sweep(now time.Time) {
+	for key, bucket := range store.buckets {
+		if len(_ExpireUses(bucket, now)) == 0 {
+			delete(store.buckets, key)
+		}
+	}
+	store.lastSweep = now
+}
+
+// _ExpireUses removes the uses that have expired from the provided bucket, which contains the expiry time of each use in order.
+func _ExpireUses(bucket []time.Time, now time.Time) []time.Time {
+	index := 0
+	for index < len(bucket) && !bucket[index].After(now) {
+		index++
+	}
+	return bucket[index:]
+}
+
+// SetCooldownStore sets the store used to track uses of commands with cooldowns.
+// Passing nil restores a new MemoryCooldownStore.
+func (parser *Parser) SetCooldownStore(store CooldownStore) {
+	if store == nil {
+		store = NewMemoryCooldownStore()
+	}
+	parser.cooldowns = store
+}
+
+// checkCooldown records a use of the command, returning a *CooldownError if its cooldown does not allow another use yet.
+func (parser *Parser) checkCooldown(ctx *Context, command *Command) error {
+	if command.cooldown == nil {
+		return nil
+	}
+
+	key := command.name + ":" + _CooldownBucketKey(ctx.Message, command.cooldown.bucket)
+	remaining, err := parser.cooldowns.Take(ctx, key, command.cooldown.uses, command.cooldown.per)
+	if err != nil {
+		return fmt.Errorf("unable to check cooldown: %w", err)
+	}
+	if remaining > 0 {
+		return &CooldownError{Command: command.name, Remaining: remaining}
+	}
+	return nil
+}
+
+// _CooldownBucketKey returns the key identifying the bucket the provided message belongs to.
+func _CooldownBucketKey(message *discordgo.MessageCreate, bucket CooldownBucket) string {
+	switch bucket {
+	case BucketUser:
+		if message.Author != nil {
+			return "user:" + message.Author.ID
+		}
+		return "user:"
+	case BucketChannel:
+		return "channel:" + message.ChannelID
+	case BucketGuild:
+		if message.GuildID == "" {
+			return "channel:" + message.ChannelID
+		}
+		return "guild:" + message.GuildID
+	}
+	return "global"
+}
+
+// _RoundUpToSecond rounds the provided duration up to the nearest whole second.
+func _RoundUpToSecond(duration time.Duration) time.Duration {
+	return (duration + time.Second - 1).Truncate(time.Second)
+}
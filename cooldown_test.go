@@ -0,0 +1,146 @@
+package parsley
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+type _FailingCooldownStore struct {
+	err error
+}
+
+func (store _FailingCooldownStore) Take(ctx context.Context, key string, uses int, per time.Duration) (time.Duration, error) {
+	return 0, store.err
+}
+
+func TestRunCommandWithCooldown(t *testing.T) {
+	now := time.Unix(0, 0)
+	store := NewMemoryCooldownStore()
+	store.now = func() time.Time { return now }
+
+	parser := New(".")
+	parser.SetCooldownStore(store)
+	called := 0
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		called++
+	}, WithCooldown(2, time.Minute, BucketUser))
+
+	message := _TestMessage(".test", "1", "2", "3")
+	for index := 0; index < 2; index++ {
+		if err := parser.RunCommand(message); err != nil {
+			t.Errorf("running command returned unexpected error: %s", err)
+		}
+	}
+
+	now = now.Add(20 * time.Second)
+	err := parser.RunCommand(message)
+	var cooldownErr *CooldownError
+	if !errors.As(err, &cooldownErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if cooldownErr.Command != "test" || cooldownErr.Remaining != 40*time.Second {
+		t.Errorf("error contained incorrect details %+v", cooldownErr)
+	}
+
+	if err := parser.RunCommand(_TestMessage(".test", "4", "2", "3")); err != nil {
+		t.Errorf("running command for another user returned unexpected error: %s", err)
+	}
+
+	now = now.Add(40 * time.Second)
+	if err := parser.RunCommand(message); err != nil {
+		t.Errorf("running command after cooldown returned unexpected error: %s", err)
+	}
+	if called != 4 {
+		t.Errorf("handler was called %d times, expected 4", called)
+	}
+}
+
+func TestRunCommandWithCooldownBuckets(t *testing.T) {
+	tests := []struct {
+		bucket CooldownBucket
+		shared *discordgo.MessageCreate
+		other  *discordgo.MessageCreate
+	}{
+		{BucketChannel, _TestMessage(".test", "4", "2", "3"), _TestMessage(".test", "1", "5", "3")},
+		{BucketGuild, _TestMessage(".test", "4", "5", "3"), _TestMessage(".test", "1", "2", "6")},
+		{BucketGlobal, _TestMessage(".test", "4", "5", "6"), nil},
+	}
+
+	for _, test := range tests {
+		parser := New(".")
+		parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithCooldown(1, time.Minute, test.bucket))
+
+		if err := parser.RunCommand(_TestMessage(".test", "1", "2", "3")); err != nil {
+			t.Errorf("bucket %d: running command returned unexpected error: %s", test.bucket, err)
+		}
+		var cooldownErr *CooldownError
+		if err := parser.RunCommand(test.shared); !errors.As(err, &cooldownErr) {
+			t.Errorf("bucket %d: running command in shared bucket did not return correct error", test.bucket)
+		}
+		if test.other != nil {
+			if err := parser.RunCommand(test.other); err != nil {
+				t.Errorf("bucket %d: running command in other bucket returned unexpected error: %s", test.bucket, err)
+			}
+		}
+	}
+}
+
+func TestRunCommandWithFailingCooldownStore(t *testing.T) {
+	errUnavailable := errors.New("store unavailable")
+
+	parser := New(".")
+	parser.SetCooldownStore(_FailingCooldownStore{errUnavailable})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		t.Errorf("handler was called")
+	}, WithCooldown(1, time.Minute, BucketUser))
+
+	err := parser.RunCommand(_TestMessage(".test", "1", "2", "3"))
+	if !errors.Is(err, errUnavailable) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestNewCommandWithInvalidCooldown(t *testing.T) {
+	tests := []struct {
+		uses int
+		per  time.Duration
+	}{
+		{0, time.Second},
+		{-1, time.Second},
+		{1, 0},
+		{1, -time.Second},
+	}
+
+	for _, test := range tests {
+		parser := New(".")
+		err := parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithCooldown(test.uses, test.per, BucketUser))
+		if !errors.Is(err, ErrInvalidCooldown) {
+			t.Errorf("registering command with %d uses per %s returned %v", test.uses, test.per, err)
+		}
+		if _, err := parser.GetCommand("test"); err == nil {
+			t.Errorf("command with %d uses per %s was registered", test.uses, test.per)
+		}
+	}
+
+	if _, err := NewMemoryCooldownStore().Take(context.Background(), "a", 0, time.Second); !errors.Is(err, ErrInvalidCooldown) {
+		t.Errorf("taking a use with an invalid cooldown returned %v", err)
+	}
+}
+
+func TestMemoryCooldownStoreSweepsExpiredBuckets(t *testing.T) {
+	now := time.Unix(0, 0)
+	store := NewMemoryCooldownStore()
+	store.now = func() time.Time { return now }
+
+	store.Take(context.Background(), "a", 1, time.Second)
+	now = now.Add(2 * time.Minute)
+	store.Take(context.Background(), "b", 1, time.Second)
+
+	if _, found := store.buckets["a"]; found {
+		t.Errorf("expired bucket was not removed")
+	}
+}
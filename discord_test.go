@@ -0,0 +1,106 @@
+package parsley
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// _FakeTransport stands in for the Discord API, recording the messages sent through it.
+// Requests to paths in responses receive the corresponding JSON, while all other requests receive status and body,
+// or a 404 error if no status is set.
+type _FakeTransport struct {
+	responses map[string]string
+	status    int
+	body      string
+	messages  []discordgo.MessageSend
+}
+
+func (transport *_FakeTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method == http.MethodPost && request.Body != nil {
+		var message discordgo.MessageSend
+		json.NewDecoder(request.Body).Decode(&message)
+		transport.messages = append(transport.messages, message)
+	}
+
+	status, body := transport.status, transport.body
+	if response, found := transport.responses[request.URL.Path]; found {
+		status, body = http.StatusOK, response
+	} else if status == 0 {
+		status, body = http.StatusNotFound, `{"message": "Unknown", "code": 10000}`
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    request,
+	}, nil
+}
+
+// _SendingTransport returns a fake transport that accepts every request, such as those sending messages.
+func _SendingTransport() *_FakeTransport {
+	return &_FakeTransport{status: http.StatusOK, body: `{"id": "1"}`}
+}
+
+// _TestSession creates a session using the provided transport, or one that fails every request if it is nil.
+// Its state contains the bot user 900 and guild 100, which has:
+//   - members 200 (user#0001, nicknamed Nickname, with role 400) and 900
+//   - roles 100 (@everyone, able to send messages) and 400 (Moderators, able to ban members and manage messages)
+//   - channels 300 (general), 301 (age-restricted, denying role 400 from managing messages) and thread 302 within 301
+//   - emoji 500 (parsley)
+func _TestSession(t *testing.T, transport *_FakeTransport) *discordgo.Session {
+	session, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatalf("creating session returned unexpected error: %s", err)
+	}
+	if transport == nil {
+		transport = &_FakeTransport{}
+	}
+	session.Client = &http.Client{Transport: transport}
+	session.State.User = &discordgo.User{ID: "900"}
+
+	err = session.State.GuildAdd(&discordgo.Guild{
+		ID: "100",
+		Members: []*discordgo.Member{
+			{GuildID: "100", Nick: "Nickname", User: &discordgo.User{ID: "200", Username: "user", Discriminator: "0001"}, Roles: []string{"400"}},
+			{GuildID: "100", User: &discordgo.User{ID: "900"}},
+		},
+		Channels: []*discordgo.Channel{
+			{ID: "300", GuildID: "100", Name: "general"},
+			{ID: "301", GuildID: "100", NSFW: true, PermissionOverwrites: []*discordgo.PermissionOverwrite{
+				{ID: "400", Type: discordgo.PermissionOverwriteTypeRole, Deny: discordgo.PermissionManageMessages},
+			}},
+		},
+		Threads: []*discordgo.Channel{
+			{ID: "302", GuildID: "100", ParentID: "301", Type: discordgo.ChannelTypeGuildPublicThread},
+		},
+		Roles: []*discordgo.Role{
+			{ID: "100", Name: "@everyone", Permissions: discordgo.PermissionSendMessages},
+			{ID: "400", Name: "Moderators", Permissions: discordgo.PermissionBanMembers | discordgo.PermissionManageMessages},
+		},
+		Emojis: []*discordgo.Emoji{
+			{ID: "500", Name: "parsley"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("adding guild to state returned unexpected error: %s", err)
+	}
+
+	return session
+}
+
+// _TestMessage creates a message with the provided content, sent by the provided user in the provided channel and guild.
+func _TestMessage(content, authorID, channelID, guildID string) *discordgo.MessageCreate {
+	return &discordgo.MessageCreate{Message: &discordgo.Message{
+		Content:   content,
+		Author:    &discordgo.User{ID: authorID},
+		ChannelID: channelID,
+		GuildID:   guildID,
+	}}
+}
@@ -0,0 +1,346 @@
+package parsley
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+var (
+	_UserMentionPattern    = regexp.MustCompile(`^<@!?(\d+)>$`)
+	_ChannelMentionPattern = regexp.MustCompile(`^<#(\d+)>$`)
+	_RoleMentionPattern    = regexp.MustCompile(`^<@&(\d+)>$`)
+	_EmojiPattern          = regexp.MustCompile(`^<(a?):(\w+):(\d+)>$`)
+	_EmojiNamePattern      = regexp.MustCompile(`^:?\w+:?$`)
+	_SnowflakePattern      = regexp.MustCompile(`^\d+$`)
+)
+
+// _EmojiRunes contains the characters that make up standard Unicode emoji: pictographs, symbols and regional indicators,
+// along with the joiners, variation selectors, skin tone modifiers, keycaps and tags used to build emoji sequences.
+var _EmojiRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00a9, Hi: 0x00a9, Stride: 1},
+		{Lo: 0x00ae, Hi: 0x00ae, Stride: 1},
+		{Lo: 0x200d, Hi: 0x200d, Stride: 1},
+		{Lo: 0x203c, Hi: 0x203c, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x20e3, Hi: 0x20e3, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x21aa, Stride: 1},
+		{Lo: 0x231a, Hi: 0x23ff, Stride: 1},
+		{Lo: 0x24c2, Hi: 0x24c2, Stride: 1},
+		{Lo: 0x25aa, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2b05, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303d, Hi: 0x303d, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+		{Lo: 0xfe0e, Hi: 0xfe0f, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1faff, Stride: 1},
+		{Lo: 0xe0020, Hi: 0xe007f, Stride: 1},
+	},
+}
+
+// _IsUnicodeEmoji returns whether the provided value is a standard Unicode emoji, such as 👍, 👍🏽, 🇨🇦 or 1️⃣.
+func _IsUnicodeEmoji(raw string) bool {
+	// Keycap emoji combine a plain digit, # or * with the keycap character, so only allow those when it is present.
+	keycap := strings.ContainsRune(raw, '\u20e3')
+	for _, char := range raw {
+		if !unicode.Is(_EmojiRunes, char) && !(keycap && (char == '#' || char == '*' || (char >= '0' && char <= '9'))) {
+			return false
+		}
+	}
+	return raw != ""
+}
+
+// _EntityConverters contains the built-in converters for Discord entity argument types.
+var _EntityConverters = map[reflect.Type]ConverterFunc{
+	reflect.TypeOf(&discordgo.User{}):    _OptionalEntity(_ResolveUser),
+	reflect.TypeOf(&discordgo.Member{}):  _OptionalEntity(_ResolveMember),
+	reflect.TypeOf(&discordgo.Channel{}): _OptionalEntity(_ResolveChannel),
+	reflect.TypeOf(&discordgo.Role{}):    _OptionalEntity(_ResolveRole),
+	reflect.TypeOf(&discordgo.Emoji{}):   _OptionalEntity(_ResolveEmoji),
+}
+
+// _OptionalEntity wraps an entity converter so that an empty value, such as from default:"", resolves to nil rather than being looked up.
+func _OptionalEntity(converter ConverterFunc) ConverterFunc {
+	return func(ctx *Context, raw string) (interface{}, error) {
+		if raw == "" {
+			return nil, nil
+		}
+		return converter(ctx, raw)
+	}
+}
+
+// _MatchID returns the ID contained in a mention matching the provided pattern, or the raw value if it is an ID.
+func _MatchID(pattern *regexp.Regexp, raw string) (string, bool) {
+	if matches := pattern.FindStringSubmatch(raw); matches != nil {
+		return matches[1], true
+	}
+	if _SnowflakePattern.MatchString(raw) {
+		return raw, true
+	}
+	return "", false
+}
+
+// _FindMember searches for a guild member by mention, ID, username, username#discriminator or nickname.
+func _FindMember(ctx *Context, raw string) (*discordgo.Member, error) {
+	if ctx == nil || ctx.Session == nil {
+		return nil, ErrSessionUnavailable
+	}
+	guildID := ctx.Message.GuildID
+	if guildID == "" {
+		return nil, ErrEntityRequiresGuild
+	}
+
+	if id, ok := _MatchID(_UserMentionPattern, raw); ok {
+		if member, err := ctx.Session.State.Member(guildID, id); err == nil {
+			return member, nil
+		}
+		member, err := ctx.Session.GuildMember(guildID, id)
+		if err != nil {
+			return nil, err
+		}
+		member.GuildID = guildID
+		return member, nil
+	}
+
+	if guild, err := ctx.Session.State.Guild(guildID); err == nil {
+		ctx.Session.State.RLock()
+		member := _MatchMember(guild.Members, raw)
+		ctx.Session.State.RUnlock()
+		if member != nil {
+			return member, nil
+		}
+	}
+
+	username := raw
+	if separator := strings.LastIndexByte(raw, '#'); separator != -1 {
+		username = raw[:separator]
+	}
+	members, err := ctx.Session.GuildMembersSearch(guildID, username, 100)
+	if err != nil {
+		return nil, err
+	}
+	if member := _MatchMember(members, raw); member != nil {
+		member.GuildID = guildID
+		return member, nil
+	}
+
+	return nil, ErrEntityNotFound
+}
+
+// _MatchMember returns the first member whose username, username#discriminator or nickname matches the provided name.
+func _MatchMember(members []*discordgo.Member, name string) *discordgo.Member {
+	for _, member := range members {
+		if member.User == nil {
+			continue
+		}
+		if strings.EqualFold(member.User.Username, name) ||
+			strings.EqualFold(member.User.String(), name) ||
+			(member.Nick != "" && strings.EqualFold(member.Nick, name)) {
+			return member
+		}
+	}
+	return nil
+}
+
+func _ResolveUser(ctx *Context, raw string) (interface{}, error) {
+	if ctx == nil || ctx.Session == nil {
+		return nil, &EntityResolutionError{"user", raw, ErrSessionUnavailable}
+	}
+
+	if id, ok := _MatchID(_UserMentionPattern, raw); ok {
+		for _, user := range ctx.Message.Mentions {
+			if user.ID == id {
+				return user, nil
+			}
+		}
+		if ctx.Message.Author != nil && ctx.Message.Author.ID == id {
+			return ctx.Message.Author, nil
+		}
+		if member, err := ctx.Session.State.Member(ctx.Message.GuildID, id); err == nil && member.User != nil {
+			return member.User, nil
+		}
+		user, err := ctx.Session.User(id)
+		if err != nil {
+			return nil, &EntityResolutionError{"user", raw, err}
+		}
+		return user, nil
+	}
+
+	member, err := _FindMember(ctx, raw)
+	if err != nil {
+		return nil, &EntityResolutionError{"user", raw, err}
+	}
+	return member.User, nil
+}
+
+func _ResolveMember(ctx *Context, raw string) (interface{}, error) {
+	member, err := _FindMember(ctx, raw)
+	if err != nil {
+		return nil, &EntityResolutionError{"member", raw, err}
+	}
+	return member, nil
+}
+
+func _ResolveChannel(ctx *Context, raw string) (interface{}, error) {
+	if ctx == nil || ctx.Session == nil {
+		return nil, &EntityResolutionError{"channel", raw, ErrSessionUnavailable}
+	}
+
+	if id, ok := _MatchID(_ChannelMentionPattern, raw); ok {
+		if channel, err := ctx.Session.State.Channel(id); err == nil {
+			return channel, nil
+		}
+		channel, err := ctx.Session.Channel(id)
+		if err != nil {
+			return nil, &EntityResolutionError{"channel", raw, err}
+		}
+		return channel, nil
+	}
+
+	guildID := ctx.Message.GuildID
+	if guildID == "" {
+		return nil, &EntityResolutionError{"channel", raw, ErrEntityRequiresGuild}
+	}
+	name := strings.TrimPrefix(raw, "#")
+
+	if guild, err := ctx.Session.State.Guild(guildID); err == nil {
+		ctx.Session.State.RLock()
+		channel := _MatchChannel(guild.Channels, name)
+		ctx.Session.State.RUnlock()
+		if channel != nil {
+			return channel, nil
+		}
+	}
+
+	channels, err := ctx.Session.GuildChannels(guildID)
+	if err != nil {
+		return nil, &EntityResolutionError{"channel", raw, err}
+	}
+	if channel := _MatchChannel(channels, name); channel != nil {
+		return channel, nil
+	}
+
+	return nil, &EntityResolutionError{"channel", raw, ErrEntityNotFound}
+}
+
+// _MatchChannel returns the first channel with the provided name.
+func _MatchChannel(channels []*discordgo.Channel, name string) *discordgo.Channel {
+	for _, channel := range channels {
+		if strings.EqualFold(channel.Name, name) {
+			return channel
+		}
+	}
+	return nil
+}
+
+func _ResolveRole(ctx *Context, raw string) (interface{}, error) {
+	if ctx == nil || ctx.Session == nil {
+		return nil, &EntityResolutionError{"role", raw, ErrSessionUnavailable}
+	}
+	guildID := ctx.Message.GuildID
+	if guildID == "" {
+		return nil, &EntityResolutionError{"role", raw, ErrEntityRequiresGuild}
+	}
+
+	id, isID := _MatchID(_RoleMentionPattern, raw)
+	name := strings.TrimPrefix(raw, "@")
+
+	if guild, err := ctx.Session.State.Guild(guildID); err == nil {
+		ctx.Session.State.RLock()
+		role := _MatchRole(guild.Roles, id, isID, name)
+		ctx.Session.State.RUnlock()
+		if role != nil {
+			return role, nil
+		}
+	}
+
+	roles, err := ctx.Session.GuildRoles(guildID)
+	if err != nil {
+		return nil, &EntityResolutionError{"role", raw, err}
+	}
+	if role := _MatchRole(roles, id, isID, name); role != nil {
+		return role, nil
+	}
+
+	return nil, &EntityResolutionError{"role", raw, ErrEntityNotFound}
+}
+
+// _MatchRole returns the role with the provided ID, or the first role with the provided name if no ID was given.
+func _MatchRole(roles []*discordgo.Role, id string, isID bool, name string) *discordgo.Role {
+	for _, role := range roles {
+		if (isID && role.ID == id) || (!isID && strings.EqualFold(role.Name, name)) {
+			return role
+		}
+	}
+	return nil
+}
+
+func _ResolveEmoji(ctx *Context, raw string) (interface{}, error) {
+	if ctx == nil || ctx.Session == nil {
+		return nil, &EntityResolutionError{"emoji", raw, ErrSessionUnavailable}
+	}
+	guildID := ctx.Message.GuildID
+
+	// Custom emoji from other guilds can still be used by the bot, so fall back to the details contained in the emoji itself.
+	if matches := _EmojiPattern.FindStringSubmatch(raw); matches != nil {
+		if emoji, err := ctx.Session.State.Emoji(guildID, matches[3]); err == nil {
+			return emoji, nil
+		}
+		return &discordgo.Emoji{ID: matches[3], Name: matches[2], Animated: matches[1] == "a"}, nil
+	}
+
+	// Standard Unicode emoji, such as 👍, are identified by the emoji itself.
+	if !_EmojiNamePattern.MatchString(raw) {
+		if _IsUnicodeEmoji(raw) {
+			return &discordgo.Emoji{Name: raw}, nil
+		}
+		return nil, &EntityResolutionError{"emoji", raw, ErrEntityNotFound}
+	}
+
+	if guildID == "" {
+		return nil, &EntityResolutionError{"emoji", raw, ErrEntityRequiresGuild}
+	}
+
+	id, isID := raw, _SnowflakePattern.MatchString(raw)
+	name := strings.Trim(raw, ":")
+
+	if guild, err := ctx.Session.State.Guild(guildID); err == nil {
+		ctx.Session.State.RLock()
+		emoji := _MatchEmoji(guild.Emojis, id, isID, name)
+		ctx.Session.State.RUnlock()
+		if emoji != nil {
+			return emoji, nil
+		}
+	}
+
+	emojis, err := ctx.Session.GuildEmojis(guildID)
+	if err != nil {
+		return nil, &EntityResolutionError{"emoji", raw, err}
+	}
+	if emoji := _MatchEmoji(emojis, id, isID, name); emoji != nil {
+		return emoji, nil
+	}
+
+	return nil, &EntityResolutionError{"emoji", raw, ErrEntityNotFound}
+}
+
+// _MatchEmoji returns the emoji with the provided ID, or the first emoji with the provided name if no ID was given.
+func _MatchEmoji(emojis []*discordgo.Emoji, id string, isID bool, name string) *discordgo.Emoji {
+	for _, emoji := range emojis {
+		if (isID && emoji.ID == id) || (!isID && strings.EqualFold(emoji.Name, name)) {
+			return emoji
+		}
+	}
+	return nil
+}
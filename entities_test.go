@@ -0,0 +1,288 @@
+package parsley
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestRunCommandWithUserArgument(t *testing.T) {
+	for _, input := range []string{"<@200>", "<@!200>", "200", "user", "user#0001", "nickname"} {
+		t.Run(input, func(t *testing.T) {
+			parser := New(".")
+			called := false
+			parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+				User *discordgo.User
+			}) {
+				called = true
+				if args.User == nil || args.User.ID != "200" {
+					t.Errorf("handler was not passed correct user")
+				}
+			})
+
+			err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test "+input, "200", "300", "100"))
+			if err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+			if !called {
+				t.Errorf("handler was not called")
+			}
+		})
+	}
+}
+
+func TestRunCommandWithUserArgumentFromRESTFallback(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		User *discordgo.User
+	}) {
+		if args.User == nil || args.User.Username != "remote" {
+			t.Errorf("handler was not passed correct user")
+		}
+	})
+
+	session := _TestSession(t, &_FakeTransport{responses: map[string]string{
+		"/api/v9/users/201": `{"id": "201", "username": "remote"}`,
+	}})
+	err := parser.Execute(context.Background(), session, _TestMessage(".test <@201>", "200", "300", "100"))
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithUnknownUserArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		User *discordgo.User
+	}) {
+		t.Errorf("handler was called despite unknown user")
+	})
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test <@999>", "200", "300", "100"))
+	var resolutionErr *EntityResolutionError
+	if !errors.As(err, &resolutionErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if resolutionErr.Entity != "user" || resolutionErr.Value != "<@999>" {
+		t.Errorf("error contained incorrect details: %s", resolutionErr)
+	}
+}
+
+func TestRunCommandWithUserArgumentWithoutSession(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		User *discordgo.User
+	}) {
+	})
+
+	err := parser.RunCommand(_TestMessage(".test <@200>", "200", "300", "100"))
+	if !errors.Is(err, ErrSessionUnavailable) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestRunCommandWithMemberArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Member *discordgo.Member
+	}) {
+		if args.Member == nil || args.Member.Nick != "Nickname" {
+			t.Errorf("handler was not passed correct member")
+		}
+	})
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test <@200>", "200", "300", "100"))
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithMemberArgumentOutsideGuild(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Member *discordgo.Member
+	}) {
+	})
+
+	message := _TestMessage(".test <@200>", "200", "300", "100")
+	message.GuildID = ""
+	err := parser.Execute(context.Background(), _TestSession(t, nil), message)
+	if !errors.Is(err, ErrEntityRequiresGuild) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestRunCommandWithChannelArgument(t *testing.T) {
+	for _, input := range []string{"<#300>", "300", "general", "#general"} {
+		t.Run(input, func(t *testing.T) {
+			parser := New(".")
+			parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+				Channel *discordgo.Channel
+			}) {
+				if args.Channel == nil || args.Channel.ID != "300" {
+					t.Errorf("handler was not passed correct channel")
+				}
+			})
+
+			err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test "+input, "200", "300", "100"))
+			if err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRunCommandWithUnknownChannelArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Channel *discordgo.Channel
+	}) {
+	})
+
+	session := _TestSession(t, &_FakeTransport{responses: map[string]string{
+		"/api/v9/guilds/100/channels": `[]`,
+	}})
+	err := parser.Execute(context.Background(), session, _TestMessage(".test random", "200", "300", "100"))
+	if !errors.Is(err, ErrEntityNotFound) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestRunCommandWithRoleArgument(t *testing.T) {
+	for _, input := range []string{"<@&400>", "400", "moderators", "@Moderators"} {
+		t.Run(input, func(t *testing.T) {
+			parser := New(".")
+			parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+				Role *discordgo.Role
+			}) {
+				if args.Role == nil || args.Role.ID != "400" {
+					t.Errorf("handler was not passed correct role")
+				}
+			})
+
+			err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test "+input, "200", "300", "100"))
+			if err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRunCommandWithEmojiArgument(t *testing.T) {
+	for _, input := range []string{"<:parsley:500>", "500", "parsley", ":parsley:"} {
+		t.Run(input, func(t *testing.T) {
+			parser := New(".")
+			parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+				Emoji *discordgo.Emoji
+			}) {
+				if args.Emoji == nil || args.Emoji.ID != "500" {
+					t.Errorf("handler was not passed correct emoji")
+				}
+			})
+
+			err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test "+input, "200", "300", "100"))
+			if err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRunCommandWithEmojiArgumentFromOtherGuild(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Emoji *discordgo.Emoji
+	}) {
+		if args.Emoji == nil || args.Emoji.ID != "600" || args.Emoji.Name != "other" || !args.Emoji.Animated {
+			t.Errorf("handler was not passed correct emoji")
+		}
+	})
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test <a:other:600>", "200", "300", "100"))
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithUnicodeEmojiArgument(t *testing.T) {
+	for _, guildID := range []string{"", "100"} {
+		parser := New(".")
+		parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+			Emoji *discordgo.Emoji
+		}) {
+			if args.Emoji == nil || args.Emoji.ID != "" || args.Emoji.Name != "👍" {
+				t.Errorf("handler was not passed correct emoji")
+			}
+		})
+
+		message := _TestMessage(".test 👍", "200", "300", "100")
+		message.GuildID = guildID
+		err := parser.Execute(context.Background(), _TestSession(t, nil), message)
+		if err != nil {
+			t.Errorf("running command in guild %q returned unexpected error: %s", guildID, err)
+		}
+	}
+}
+
+func TestRunCommandWithInvalidEmojiArgument(t *testing.T) {
+	for _, input := range []string{"not-an-emoji!", "<@123>", "#", "👍a"} {
+		t.Run(input, func(t *testing.T) {
+			parser := New(".")
+			parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+				Emoji *discordgo.Emoji
+			}) {
+				t.Errorf("handler was called with invalid emoji")
+			})
+
+			err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test "+input, "200", "300", "100"))
+			if !errors.Is(err, ErrEntityNotFound) {
+				t.Errorf("running command did not return correct error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRunCommandWithUnicodeEmojiSequenceArgument(t *testing.T) {
+	for _, input := range []string{"👍🏽", "❤️", "🇨🇦", "1️⃣", "#️⃣", "👨‍👩‍👧", "🏴󠁧󠁢󠁳󠁣󠁴󠁿", "©️"} {
+		t.Run(input, func(t *testing.T) {
+			parser := New(".")
+			parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+				Emoji *discordgo.Emoji
+			}) {
+				if args.Emoji == nil || args.Emoji.ID != "" || args.Emoji.Name != input {
+					t.Errorf("handler was not passed correct emoji")
+				}
+			})
+
+			err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test "+input, "200", "300", "100"))
+			if err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRunCommandWithOmittedOptionalEntityArguments(t *testing.T) {
+	parser := New(".")
+	called := false
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		User    *discordgo.User    `default:""`
+		Member  *discordgo.Member  `default:""`
+		Channel *discordgo.Channel `default:""`
+	}) {
+		called = true
+		if args.User != nil || args.Member != nil || args.Channel != nil {
+			t.Errorf("handler was passed unexpected entities %+v", args)
+		}
+	})
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test", "200", "300", "100"))
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
@@ -0,0 +1,164 @@
+package parsley
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// ErrorHandler handles errors that occur while running commands from messages received by RegisterHandler.
+// The provided context contains the command and arguments being run, if they were resolved before the error occurred.
+type ErrorHandler func(ctx *Context, message *discordgo.MessageCreate, err error)
+
+// SetErrorHandler sets the handler used to report errors that occur while running commands from messages received by RegisterHandler.
+// Passing nil restores DefaultErrorHandler.
+func (parser *Parser) SetErrorHandler(handler ErrorHandler) {
+	if handler == nil {
+		handler = DefaultErrorHandler
+	}
+	parser.errorHandler = handler
+}
+
+// DefaultErrorHandler replies to the message that caused an error with a description of the error, as produced by FormatError.
+// If the reply cannot be sent, the failure is logged.
+// Panics are also logged along with their stack trace, as the reply does not include them.
+func DefaultErrorHandler(ctx *Context, message *discordgo.MessageCreate, err error) {
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		log.Printf("Panic running command %q: %v\n%s", message.Content, panicErr.Value, panicErr.Stack)
+	}
+
+	if ctx.Session == nil {
+		log.Printf("Error running command %q: %s", message.Content, err)
+		return
+	}
+
+	if _, sendErr := ctx.Session.ChannelMessageSend(message.ChannelID, FormatError(err)); sendErr != nil {
+		log.Printf("Failed to send error message for %q: %s (original error: %s)", message.Content, sendErr, err)
+	}
+}
+
+// IgnoreUnknownCommands wraps the provided error handler, silently ignoring errors caused by messages that do not refer to a known command.
+// Errors caused by unknown subcommands of a known group are still passed to the wrapped handler.
+func IgnoreUnknownCommands(next ErrorHandler) ErrorHandler {
+	return func(ctx *Context, message *discordgo.MessageCreate, err error) {
+		var subcommandErr *UnknownSubcommandError
+		if errors.Is(err, ErrUnknownCommand) && !errors.As(err, &subcommandErr) {
+			return
+		}
+		next(ctx, message, err)
+	}
+}
+
+// FormatError returns a user-facing description of an error returned while running a command.
+// Errors defined by this package are described in plain language, while any other errors are shown verbatim.
+func FormatError(err error) string {
+	var argErr *ArgumentError
+	var missingErr *MissingArgumentsError
+	var subcommandErr *UnknownSubcommandError
+	var entityErr *EntityResolutionError
+	var panicErr *PanicError
+	var permissionsErr *MissingPermissionsError
+	var cooldownErr *CooldownError
+	var tokenizeErr *TokenizeError
+	var flagErr *FlagError
+	var conflictErr *ArgumentConflictError
+
+	switch {
+	case errors.As(err, &argErr):
+		reason := fmt.Sprintf("expected %s", _Article(argErr.Type))
+		if errors.As(argErr.Err, &entityErr) {
+			reason = _FormatEntityError(entityErr)
+		}
+		return _WithUsage(fmt.Sprintf("Invalid value `%s` for **%s**: %s.", argErr.Value, argErr.Argument, reason), argErr.Usage)
+	case errors.As(err, &missingErr):
+		return _WithUsage(fmt.Sprintf("Missing required arguments: **%s**.", strings.Join(missingErr.Arguments, "**, **")), missingErr.Usage)
+	case errors.As(err, &flagErr):
+		message := fmt.Sprintf("Unknown option `%s`.", flagErr.Flag)
+		if errors.Is(flagErr, ErrFlagMissingValue) {
+			message = fmt.Sprintf("Option `%s` requires a value.", flagErr.Flag)
+		}
+		return _WithUsage(message, flagErr.Usage)
+	case errors.As(err, &conflictErr):
+		message := fmt.Sprintf("**%s** was provided more than once.", conflictErr.Argument)
+		if errors.Is(conflictErr, ErrArgumentPositionalAndNamed) {
+			message = fmt.Sprintf("**%s** was provided both in order and by name.", conflictErr.Argument)
+		}
+		return _WithUsage(message, conflictErr.Usage)
+	case errors.As(err, &subcommandErr):
+		message := fmt.Sprintf("Unknown subcommand `%s` for `%s`.", subcommandErr.Subcommand, subcommandErr.Group)
+		if subcommandErr.Subcommand == "" {
+			message = fmt.Sprintf("`%s` requires a subcommand.", subcommandErr.Group)
+		}
+		if len(subcommandErr.Subcommands) == 0 {
+			return fmt.Sprintf("%s It has no subcommands yet.", message)
+		}
+		return fmt.Sprintf("%s Available subcommands: %s.", message, strings.Join(subcommandErr.Subcommands, ", "))
+	case errors.As(err, &permissionsErr):
+		if permissionsErr.Bot {
+			return fmt.Sprintf("I need the following permissions to run this command: %s.", strings.Join(PermissionNames(permissionsErr.Permissions), ", "))
+		}
+		return fmt.Sprintf("You need the following permissions to run this command: %s.", strings.Join(PermissionNames(permissionsErr.Permissions), ", "))
+	case errors.As(err, &cooldownErr):
+		return fmt.Sprintf("This command is on cooldown. Try again in %s.", _RoundUpToSecond(cooldownErr.Remaining))
+	case errors.As(err, &panicErr):
+		return "An internal error occurred running your command."
+	case errors.Is(err, ErrUnknownCommand):
+		return "Unknown command."
+	case errors.Is(err, ErrGuildOnly):
+		return "This command can only be used in a server."
+	case errors.Is(err, ErrDMOnly):
+		return "This command can only be used in direct messages."
+	case errors.Is(err, ErrOwnerOnly):
+		return "This command can only be used by the bot's owners."
+	case errors.Is(err, ErrNSFWOnly):
+		return "This command can only be used in age-restricted channels."
+	case errors.Is(err, ErrChannelNotAllowed):
+		return "This command cannot be used in this channel."
+	case errors.Is(err, ErrRoleNotAllowed):
+		return "You do not have a role that is allowed to use this command."
+	case errors.Is(err, ErrInvalidPrefix):
+		return fmt.Sprintf("Prefixes must be at most %d characters long and cannot contain spaces.", _PrefixLengthLimit)
+	case errors.As(err, &tokenizeErr) && errors.Is(err, ErrUnclosedQuote):
+		return fmt.Sprintf("Your message contains a quote that is never closed, starting at character %d.", tokenizeErr.Position)
+	case errors.As(err, &tokenizeErr) && errors.Is(err, ErrUnclosedCodeBlock):
+		return fmt.Sprintf("Your message contains code that is never closed, starting at character %d.", tokenizeErr.Position)
+	case errors.Is(err, ErrUnclosedQuote):
+		return "Your message contains a quote that is never closed."
+	case errors.Is(err, ErrUnclosedCodeBlock):
+		return "Your message contains code that is never closed."
+	case errors.Is(err, ErrKwargsMustBeAtEnd):
+		return "Named arguments such as `Name=value` must come after all other arguments."
+	}
+
+	return fmt.Sprintf("An error occurred running your command:\n```\n%s\n```", err.Error())
+}
+
+// _FormatEntityError returns a user-facing description of why an argument could not be resolved to a Discord entity.
+func _FormatEntityError(err *EntityResolutionError) string {
+	switch {
+	case errors.Is(err, ErrEntityRequiresGuild):
+		return fmt.Sprintf("%ss can only be found when running commands in a server", err.Entity)
+	case errors.Is(err, ErrEntityNotFound):
+		return fmt.Sprintf("no matching %s was found", err.Entity)
+	}
+	return fmt.Sprintf("unable to find a matching %s", err.Entity)
+}
+
+// _Article prefixes the provided type name with the appropriate indefinite article.
+func _Article(typeName string) string {
+	if typeName != "" && strings.ContainsRune("aeioAEIO", rune(typeName[0])) {
+		return "an " + typeName
+	}
+	return "a " + typeName
+}
+
+func _WithUsage(message, usage string) string {
+	if usage == "" {
+		return message
+	}
+	return fmt.Sprintf("%s\nUsage: `%s`", message, usage)
+}
@@ -0,0 +1,132 @@
+package parsley
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestDefaultErrorHandlerSendsFormattedError(t *testing.T) {
+	transport := _SendingTransport()
+	session := _TestSession(t, transport)
+	message := &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!unknown", ChannelID: "1"}}
+
+	DefaultErrorHandler(&Context{Context: context.Background(), Session: session, Message: message}, message, ErrUnknownCommand)
+
+	if len(transport.messages) != 1 {
+		t.Fatalf("error handler sent %d messages, expected 1", len(transport.messages))
+	}
+	if transport.messages[0].Content != "Unknown command." {
+		t.Errorf("error handler sent incorrect message %q", transport.messages[0].Content)
+	}
+}
+
+func TestDefaultErrorHandlerWithFailingSend(t *testing.T) {
+	session := _TestSession(t, &_FakeTransport{status: http.StatusForbidden, body: `{"code": 50013, "message": "Missing Permissions"}`})
+	message := &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!unknown", ChannelID: "1"}}
+
+	DefaultErrorHandler(&Context{Context: context.Background(), Session: session, Message: message}, message, ErrUnknownCommand)
+}
+
+func TestIgnoreUnknownCommands(t *testing.T) {
+	var handled []error
+	handler := IgnoreUnknownCommands(func(ctx *Context, message *discordgo.MessageCreate, err error) {
+		handled = append(handled, err)
+	})
+
+	subcommandErr := &UnknownSubcommandError{Group: "config", Subcommands: []string{"set"}}
+	for _, err := range []error{
+		ErrUnknownCommand,
+		subcommandErr,
+		ErrRequiredArgumentMissing,
+	} {
+		handler(&Context{}, &discordgo.MessageCreate{Message: &discordgo.Message{}}, err)
+	}
+
+	if len(handled) != 2 || handled[0] != subcommandErr || handled[1] != ErrRequiredArgumentMissing {
+		t.Errorf("error handler was passed incorrect errors %v", handled)
+	}
+}
+
+func TestErrorHandlerReceivesContext(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+		User string
+		Days int `default:"0"`
+	}) {
+	})
+
+	ctx := &Context{Context: context.Background(), Message: &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!ban user ABC"}}}
+	err := parser.run(ctx)
+	if ctx.Command != "ban" {
+		t.Errorf("context contained incorrect command name %s", ctx.Command)
+	}
+
+	var argErr *ArgumentError
+	if !errors.As(err, &argErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+}
+
+func TestSetErrorHandlerWithNil(t *testing.T) {
+	parser := New("!")
+	parser.SetErrorHandler(func(ctx *Context, message *discordgo.MessageCreate, err error) {})
+	parser.SetErrorHandler(nil)
+
+	if parser.errorHandler == nil {
+		t.Errorf("error handler was not restored to default")
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{
+			&ArgumentError{Argument: "Days", Position: 2, Value: "ABC", Type: "int", Usage: "!ban <User> [Days=0]"},
+			"Invalid value `ABC` for **Days**: expected an int.\nUsage: `!ban <User> [Days=0]`",
+		},
+		{
+			&ArgumentError{Argument: "Target", Position: 1, Value: "bob", Type: "User", Err: &EntityResolutionError{"user", "bob", ErrEntityNotFound}},
+			"Invalid value `bob` for **Target**: no matching user was found.",
+		},
+		{
+			&MissingArgumentsError{Arguments: []string{"User", "Reason"}, Usage: "!ban <User> <Reason>"},
+			"Missing required arguments: **User**, **Reason**.\nUsage: `!ban <User> <Reason>`",
+		},
+		{
+			&UnknownSubcommandError{Group: "config", Subcommand: "unknown", Subcommands: []string{"get", "set"}},
+			"Unknown subcommand `unknown` for `config`. Available subcommands: get, set.",
+		},
+		{
+			&UnknownSubcommandError{Group: "config", Subcommands: []string{"get", "set"}},
+			"`config` requires a subcommand. Available subcommands: get, set.",
+		},
+		{&UnknownSubcommandError{Group: "config", Subcommands: []string{}}, "`config` requires a subcommand. It has no subcommands yet."},
+		{
+			&FlagError{Flag: "--force", Usage: "!ban <User> [Days=0]", Err: ErrUnknownFlag},
+			"Unknown option `--force`.\nUsage: `!ban <User> [Days=0]`",
+		},
+		{&FlagError{Flag: "-r", Err: ErrFlagMissingValue}, "Option `-r` requires a value."},
+		{
+			&ArgumentConflictError{Argument: "Reason", Usage: "!ban <User> [Reason=]", Err: ErrDuplicateArgument},
+			"**Reason** was provided more than once.\nUsage: `!ban <User> [Reason=]`",
+		},
+		{&ArgumentConflictError{Argument: "User", Err: ErrArgumentPositionalAndNamed}, "**User** was provided both in order and by name."},
+		{ErrUnknownCommand, "Unknown command."},
+		{&CooldownError{Command: "test", Remaining: 2500 * time.Millisecond}, "This command is on cooldown. Try again in 3s."},
+		{&PanicError{Command: "ban", Value: "nil map"}, "An internal error occurred running your command."},
+		{errors.New("database unavailable"), "An error occurred running your command:\n```\ndatabase unavailable\n```"},
+	}
+
+	for _, test := range tests {
+		if message := FormatError(test.err); message != test.expected {
+			t.Errorf("formatting %v returned %q, expected %q", test.err, message, test.expected)
+		}
+	}
+}
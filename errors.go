@@ -1,6 +1,12 @@
 package parsley
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
 
 // ErrHandlerNotFunction occurs when a provided handler is not a function.
 var ErrHandlerNotFunction error = errors.New("provided command handler is not a function")
@@ -9,16 +15,335 @@ var ErrHandlerNotFunction error = errors.New("provided command handler is not a
 var ErrHandlerInvalidParameterCount error = errors.New("provided command handler expects incorrect number of parameters")
 
 // ErrHandlerInvalidFirstParameterType occurs when a provided handler does not expect a first parameter of the correct type.
-var ErrHandlerInvalidFirstParameterType error = errors.New("incorrect first parameter type for handler, first parameter must be of type *discordgo.MessageCreate")
+var ErrHandlerInvalidFirstParameterType error = errors.New("incorrect first parameter type for handler, first parameter must be of type *parsley.Context or *discordgo.MessageCreate")
 
 // ErrHandlerInvalidSecondParameterType occurs when a provided handler does not expect a second parameter of the correct type.
 var ErrHandlerInvalidSecondParameterType error = errors.New("incorrect second parameter type for handler, second parameter must be of type struct")
 
+// ErrHandlerInvalidReturnType occurs when a provided handler returns something other than nothing or a single error.
+var ErrHandlerInvalidReturnType error = errors.New("incorrect return type for handler, handler must return either nothing or an error")
+
 // ErrUnknownCommand occurs when the provided message or function call contains an unknown command.
 var ErrUnknownCommand error = errors.New("unknown command")
 
+// ErrCommandNameConflict occurs when registering a command or group using a name that is already in use.
+var ErrCommandNameConflict error = errors.New("name is already in use by another command or group")
+
+// ErrInvalidCooldown occurs when registering a command with a cooldown that does not allow at least one use over a positive duration.
+var ErrInvalidCooldown error = errors.New("cooldown must allow at least one use per positive duration")
+
 // ErrRequiredArgumentMissing occurs when the provided message does not have values for all required arguments.
 var ErrRequiredArgumentMissing error = errors.New("one or more required arguments were not provided")
 
-// ErrKwargsMustBeAtEnd occurs when a user provides keyword arguments in the middle of positional arguments
+// ErrKwargsMustBeAtEnd occurs when a user provides keyword arguments in the middle of positional arguments, unless flexible ordering is enabled
 var ErrKwargsMustBeAtEnd error = errors.New("keyword arguments must be provided as the last arguments")
+
+// ErrUnclosedQuote occurs when the provided message contains a quote that is never closed.
+var ErrUnclosedQuote error = errors.New("message contains an unclosed quote")
+
+// ErrUnclosedCodeBlock occurs when the provided message contains a code block or inline code that is never closed.
+var ErrUnclosedCodeBlock error = errors.New("message contains an unclosed code block")
+
+// ErrRestArgumentNotString occurs when an argument tagged with rest:"true" is not a string.
+var ErrRestArgumentNotString error = errors.New("rest arguments must be of type string")
+
+// ErrGreedyArgumentNotLast occurs when a slice or rest argument is not the last field of its argument struct.
+var ErrGreedyArgumentNotLast error = errors.New("slice and rest arguments must be the last argument")
+
+// ErrConverterInvalidResultType occurs when a registered converter returns a value that cannot be assigned to its argument.
+var ErrConverterInvalidResultType error = errors.New("converter returned value of incorrect type")
+
+// ErrSessionUnavailable occurs when a Discord entity argument is provided to a command run without a session.
+var ErrSessionUnavailable error = errors.New("no session is available to resolve discord entities, use Execute instead of RunCommand")
+
+// ErrEntityRequiresGuild occurs when a Discord entity argument that only exists within a guild is provided outside of one.
+var ErrEntityRequiresGuild error = errors.New("entity can only be resolved within a guild")
+
+// ErrEntityNotFound occurs when a Discord entity argument does not match any known entity.
+var ErrEntityNotFound error = errors.New("entity not found")
+
+// ErrGuildOnly occurs when a command that can only be run within guilds is run elsewhere.
+var ErrGuildOnly error = errors.New("command can only be run within a guild")
+
+// ErrDMOnly occurs when a command that can only be run within direct messages is run elsewhere.
+var ErrDMOnly error = errors.New("command can only be run within direct messages")
+
+// ErrOwnerOnly occurs when a command that can only be run by the bot's owners is run by another user.
+var ErrOwnerOnly error = errors.New("command can only be run by the bot's owners")
+
+// ErrNSFWOnly occurs when a command that can only be run within age-restricted channels is run elsewhere.
+var ErrNSFWOnly error = errors.New("command can only be run within age-restricted channels")
+
+// ErrChannelNotAllowed occurs when a command is run outside of the channels it is allowed to be run within.
+var ErrChannelNotAllowed error = errors.New("command cannot be run within this channel")
+
+// ErrRoleNotAllowed occurs when a command is run by a user without any of the roles it is allowed to be run by.
+var ErrRoleNotAllowed error = errors.New("command can only be run by members with specific roles")
+
+// ErrInvalidPrefix occurs when attempting to set a prefix that cannot be used.
+var ErrInvalidPrefix error = errors.New("invalid prefix")
+
+// ErrInvalidFlagName occurs when an argument's short flag is not a single character.
+var ErrInvalidFlagName error = errors.New("invalid flag name")
+
+// ErrInvalidArgumentName occurs when an argument's name or one of its aliases is empty, starts with -, or contains = or whitespace.
+var ErrInvalidArgumentName error = errors.New("invalid argument name")
+
+// ErrArgumentNameConflict occurs when an argument's name or one of its aliases is already used by another argument,
+// ignoring case so that case-insensitive keywords can be enabled.
+var ErrArgumentNameConflict error = errors.New("argument name is already used by another argument")
+
+// ErrFlagNameConflict occurs when more than one argument uses the same flag name.
+var ErrFlagNameConflict error = errors.New("flag name is already used by another argument")
+
+// ErrUnknownFlag occurs when the provided message contains a flag that does not match any of the command's arguments.
+var ErrUnknownFlag error = errors.New("unknown flag")
+
+// ErrFlagMissingValue occurs when a flag that requires a value is provided as the last argument without one.
+var ErrFlagMissingValue error = errors.New("flag requires a value")
+
+// ErrDuplicateArgument occurs when an argument other than a variadic argument is provided by name more than once.
+var ErrDuplicateArgument error = errors.New("argument was provided more than once")
+
+// ErrArgumentPositionalAndNamed occurs when an argument is provided both positionally and by name.
+var ErrArgumentPositionalAndNamed error = errors.New("argument was provided both positionally and by name")
+
+// UnexportedArgumentError occurs when an argument struct contains an unexported field, which cannot be populated.
+type UnexportedArgumentError struct {
+	Field string
+	Type  reflect.Type
+}
+
+func (err *UnexportedArgumentError) Error() string {
+	return fmt.Sprintf("argument %s of type %s is unexported", err.Field, err.Type)
+}
+
+// UnsupportedArgumentTypeError occurs when an argument struct contains a field of a type that cannot be parsed.
+type UnsupportedArgumentTypeError struct {
+	Field string
+	Type  reflect.Type
+}
+
+func (err *UnsupportedArgumentTypeError) Error() string {
+	return fmt.Sprintf("argument %s has unsupported type %s", err.Field, err.Type)
+}
+
+// InvalidDefaultValueError occurs when the default value of an argument cannot be parsed into the argument's type.
+type InvalidDefaultValueError struct {
+	Field string
+	Type  reflect.Type
+	Value string
+	Err   error
+}
+
+func (err *InvalidDefaultValueError) Error() string {
+	return fmt.Sprintf("default value %q for argument %s is not a valid %s: %s", err.Value, err.Field, err.Type, err.Err)
+}
+
+func (err *InvalidDefaultValueError) Unwrap() error {
+	return err.Err
+}
+
+// EntityResolutionError occurs when an argument cannot be resolved to the Discord entity its type requires.
+type EntityResolutionError struct {
+	Entity string
+	Value  string
+	Err    error
+}
+
+func (err *EntityResolutionError) Error() string {
+	return fmt.Sprintf("unable to resolve %s %q: %s", err.Entity, err.Value, err.Err)
+}
+
+func (err *EntityResolutionError) Unwrap() error {
+	return err.Err
+}
+
+// UnknownSubcommandError occurs when a group is invoked without a subcommand, or with a subcommand it does not contain.
+// It matches ErrUnknownCommand when used with errors.Is.
+type UnknownSubcommandError struct {
+	Group       string
+	Subcommand  string
+	Subcommands []string
+}
+
+func (err *UnknownSubcommandError) Error() string {
+	message := fmt.Sprintf("unknown subcommand %q for %s", err.Subcommand, err.Group)
+	if err.Subcommand == "" {
+		message = fmt.Sprintf("%s requires a subcommand", err.Group)
+	}
+	if len(err.Subcommands) == 0 {
+		return message + ", but it has no subcommands"
+	}
+	return fmt.Sprintf("%s, valid subcommands are: %s", message, strings.Join(err.Subcommands, ", "))
+}
+
+func (err *UnknownSubcommandError) Unwrap() error {
+	return ErrUnknownCommand
+}
+
+// _UsageError is implemented by errors that include the usage of the command they occurred while running.
+type _UsageError interface {
+	error
+	setUsage(usage string)
+}
+
+// ArgumentError occurs when the value provided for an argument cannot be parsed into the argument's type.
+// Position is the 1-based position of the argument within the command's arguments.
+type ArgumentError struct {
+	Argument string
+	Position int
+	Value    string
+	Type     string
+	Usage    string
+	Err      error
+}
+
+func (err *ArgumentError) Error() string {
+	message := fmt.Sprintf(
+		"error parsing arguments: invalid value %q for argument %s (position %d), expected %s: %s",
+		err.Value, err.Argument, err.Position, err.Type, err.Err,
+	)
+	if err.Usage != "" {
+		message += "\nUsage: " + err.Usage
+	}
+	return message
+}
+
+func (err *ArgumentError) Unwrap() error {
+	return err.Err
+}
+
+func (err *ArgumentError) setUsage(usage string) {
+	err.Usage = usage
+}
+
+// MissingArgumentsError occurs when the provided message does not have values for all required arguments.
+// It matches ErrRequiredArgumentMissing when used with errors.Is.
+type MissingArgumentsError struct {
+	Arguments []string
+	Usage     string
+}
+
+func (err *MissingArgumentsError) Error() string {
+	message := fmt.Sprintf("error parsing arguments: %s: %s", ErrRequiredArgumentMissing, strings.Join(err.Arguments, ", "))
+	if err.Usage != "" {
+		message += "\nUsage: " + err.Usage
+	}
+	return message
+}
+
+func (err *MissingArgumentsError) Unwrap() error {
+	return ErrRequiredArgumentMissing
+}
+
+func (err *MissingArgumentsError) setUsage(usage string) {
+	err.Usage = usage
+}
+
+// PanicError occurs when a command's handler, or one of its argument converters, panics.
+// Value contains the value passed to panic, and Stack contains the stack trace of the goroutine at the time of the panic.
+type PanicError struct {
+	Command string
+	Value   interface{}
+	Stack   []byte
+}
+
+func (err *PanicError) Error() string {
+	return fmt.Sprintf("panic running command %s: %v", err.Command, err.Value)
+}
+
+// Unwrap returns the value passed to panic if it is an error, such as a runtime.Error.
+func (err *PanicError) Unwrap() error {
+	if valueErr, ok := err.Value.(error); ok {
+		return valueErr
+	}
+	return nil
+}
+
+// MissingPermissionsError occurs when the user running a command, or the bot itself, lacks permissions the command requires.
+// Permissions contains only the required permissions that are missing.
+type MissingPermissionsError struct {
+	Bot         bool
+	Permissions int64
+}
+
+func (err *MissingPermissionsError) Error() string {
+	subject := "you are"
+	if err.Bot {
+		subject = "the bot is"
+	}
+	return fmt.Sprintf("%s missing required permissions: %s", subject, strings.Join(PermissionNames(err.Permissions), ", "))
+}
+
+// CooldownError occurs when a command is run more often than its cooldown allows.
+// Remaining contains how long the user must wait before the command can be run again.
+type CooldownError struct {
+	Command   string
+	Remaining time.Duration
+}
+
+func (err *CooldownError) Error() string {
+	return fmt.Sprintf("command %s is on cooldown, try again in %s", err.Command, _RoundUpToSecond(err.Remaining))
+}
+
+// TokenizeError occurs when a message cannot be split into tokens.
+// Position is the 1-based position of the character at which the problem starts, such as a quote that is never closed.
+type TokenizeError struct {
+	Position int
+	Err      error
+}
+
+func (err *TokenizeError) Error() string {
+	return fmt.Sprintf("%s at character %d", err.Err, err.Position)
+}
+
+func (err *TokenizeError) Unwrap() error {
+	return err.Err
+}
+
+// FlagError occurs when a flag provided in a message cannot be used, such as when it is unknown or is missing its value.
+type FlagError struct {
+	Flag  string
+	Usage string
+	Err   error
+}
+
+func (err *FlagError) Error() string {
+	message := fmt.Sprintf("error parsing arguments: %s: %s", err.Err, err.Flag)
+	if err.Usage != "" {
+		message += "\nUsage: " + err.Usage
+	}
+	return message
+}
+
+func (err *FlagError) Unwrap() error {
+	return err.Err
+}
+
+func (err *FlagError) setUsage(usage string) {
+	err.Usage = usage
+}
+
+// ArgumentConflictError occurs when an argument is provided more than once, either by name or both positionally and by name.
+type ArgumentConflictError struct {
+	Argument string
+	Usage    string
+	Err      error
+}
+
+func (err *ArgumentConflictError) Error() string {
+	message := fmt.Sprintf("error parsing arguments: %s: %s", err.Err, err.Argument)
+	if err.Usage != "" {
+		message += "\nUsage: " + err.Usage
+	}
+	return message
+}
+
+func (err *ArgumentConflictError) Unwrap() error {
+	return err.Err
+}
+
+func (err *ArgumentConflictError) setUsage(usage string) {
+	err.Usage = usage
+}
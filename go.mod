@@ -5,7 +5,6 @@ go 1.19
 require (
 	github.com/bwmarrin/discordgo v0.27.1
 	github.com/go-test/deep v1.0.7
-	github.com/google/shlex v0.0.0-20191202100458-e7afc7fbc510
 )
 
 require (
@@ -0,0 +1,35 @@
+package parsley
+
+import "reflect"
+
+// Group represents a group of subcommands, invoked by providing the name of the group followed by the name of the subcommand.
+type Group struct {
+	parser  *Parser
+	command *Command
+}
+
+// NewCommand registers a new subcommand within the group.
+func (group *Group) NewCommand(name, description string, handler interface{}, options ...CommandOption) error {
+	return _NewCommand(group, name, description, handler, options)
+}
+
+// NewGroup registers a new nested group of subcommands within the group.
+// If a group with the provided name already exists, it is returned instead.
+func (group *Group) NewGroup(name, description string) (*Group, error) {
+	return group.parser.registerGroup(group.command.subcommands, group.command.name, name, description)
+}
+
+// Use registers middleware that wraps every command within the group, including the commands within nested groups.
+// Group middleware runs inside any middleware registered on the parser or on parent groups.
+func (group *Group) Use(middleware ...Middleware) {
+	group.command.middleware = append(group.command.middleware, middleware...)
+}
+
+func (group *Group) addCommand(
+	name, description string,
+	argsType reflect.Type,
+	handler func(ctx *Context, args reflect.Value) error,
+	options []CommandOption,
+) error {
+	return group.parser.registerCommand(group.command.subcommands, group.command.name, name, description, argsType, handler, options)
+}
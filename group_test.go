@@ -0,0 +1,226 @@
+package parsley
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestNewGroupWithExistingGroup(t *testing.T) {
+	parser := New("")
+
+	group1, err := parser.NewGroup("config", "")
+	if err != nil {
+		t.Errorf("adding group returned unexpected error")
+	}
+	group2, err := parser.NewGroup("config", "")
+	if err != nil {
+		t.Errorf("adding group returned unexpected error")
+	}
+
+	if group1.command != group2.command {
+		t.Errorf("adding existing group did not return existing group")
+	}
+}
+
+func TestNewGroupWithExistingCommand(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("config", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	_, err := parser.NewGroup("config", "")
+	if !errors.Is(err, ErrCommandNameConflict) {
+		t.Errorf("adding group did not return correct error")
+	}
+}
+
+func TestNewCommandWithExistingGroup(t *testing.T) {
+	parser := New("")
+	parser.NewGroup("config", "")
+
+	err := parser.NewCommand("config", "", func(message *discordgo.MessageCreate, args struct{}) {})
+	if !errors.Is(err, ErrCommandNameConflict) {
+		t.Errorf("adding command did not return correct error")
+	}
+}
+
+func TestRunCommandWithSubcommand(t *testing.T) {
+	parser := New(".")
+	group, _ := parser.NewGroup("config", "")
+	called := false
+	group.NewCommand("set", "", func(ctx *Context, args struct {
+		Key   string
+		Value string
+	}) {
+		called = true
+		if ctx.Command != "config set" {
+			t.Errorf("handler was passed incorrect command name %s", ctx.Command)
+		}
+		if args.Key != "a" || args.Value != "b" {
+			t.Errorf("handler was not passed correct args")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".config set a b"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestRunCommandWithNestedSubcommand(t *testing.T) {
+	parser := New(".")
+	group, _ := parser.NewGroup("config", "")
+	nestedGroup, _ := group.NewGroup("channel", "")
+	called := false
+	Register(nestedGroup, "set", "", func(ctx *Context, args struct {
+		Value string
+	}) error {
+		called = true
+		if ctx.Command != "config channel set" {
+			t.Errorf("handler was passed incorrect command name %s", ctx.Command)
+		}
+		if args.Value != "a" {
+			t.Errorf("handler was not passed correct args")
+		}
+		return nil
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".config channel set a"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestRunCommandWithUnknownSubcommand(t *testing.T) {
+	parser := New(".")
+	group, _ := parser.NewGroup("config", "")
+	group.NewCommand("set", "", func(message *discordgo.MessageCreate, args struct{}) {})
+	group.NewCommand("get", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".config unknown"}})
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("running command did not return correct error")
+	}
+
+	var subcommandErr *UnknownSubcommandError
+	if !errors.As(err, &subcommandErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if diff := deep.Equal(subcommandErr, &UnknownSubcommandError{
+		Group:       "config",
+		Subcommand:  "unknown",
+		Subcommands: []string{"get", "set"},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithMissingSubcommand(t *testing.T) {
+	parser := New(".")
+	group, _ := parser.NewGroup("config", "")
+	group.NewCommand("set", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".config"}})
+	var subcommandErr *UnknownSubcommandError
+	if !errors.As(err, &subcommandErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if subcommandErr.Subcommand != "" {
+		t.Errorf("error contained incorrect subcommand %s", subcommandErr.Subcommand)
+	}
+}
+
+func TestRunCommandWithEmptyGroup(t *testing.T) {
+	parser := New(".")
+	parser.NewGroup("config", "")
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".config"}})
+	var subcommandErr *UnknownSubcommandError
+	if !errors.As(err, &subcommandErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if !strings.HasSuffix(err.Error(), "config requires a subcommand, but it has no subcommands") {
+		t.Errorf("running command returned incorrect error %q", err)
+	}
+}
+
+func TestGetCommandWithSubcommand(t *testing.T) {
+	parser := New("")
+	group, _ := parser.NewGroup("config", "")
+	group.NewCommand("set", "Sets a value.", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	command, err := parser.GetCommand("config set")
+	if err != nil {
+		t.Errorf("got unexpected error")
+	}
+
+	if diff := deep.Equal(command, CommandDetails{
+		Name:        "config set",
+		Description: "Sets a value.",
+		Arguments:   []ArgumentDetails{},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestGetCommandWithUnknownSubcommand(t *testing.T) {
+	parser := New("")
+	group, _ := parser.NewGroup("config", "")
+	group.NewCommand("set", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	_, err := parser.GetCommand("config set value")
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("function did not return expected error")
+	}
+}
+
+func TestGetCommandsWithGroups(t *testing.T) {
+	parser := New("")
+	group, _ := parser.NewGroup("config", "Manages configuration.")
+	group.NewCommand("set", "Sets a value.", func(message *discordgo.MessageCreate, args struct{}) {})
+	nestedGroup, _ := group.NewGroup("channel", "Manages channel configuration.")
+	nestedGroup.NewCommand("get", "Gets a value.", func(message *discordgo.MessageCreate, args struct{}) {})
+	parser.NewCommand("ping", "Pings.", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	if diff := deep.Equal(parser.GetCommands(), []CommandDetails{
+		{
+			Name:        "config",
+			Description: "Manages configuration.",
+			Arguments:   []ArgumentDetails{},
+			Subcommands: []CommandDetails{
+				{
+					Name:        "config channel",
+					Description: "Manages channel configuration.",
+					Arguments:   []ArgumentDetails{},
+					Subcommands: []CommandDetails{
+						{
+							Name:        "config channel get",
+							Description: "Gets a value.",
+							Arguments:   []ArgumentDetails{},
+						},
+					},
+				},
+				{
+					Name:        "config set",
+					Description: "Sets a value.",
+					Arguments:   []ArgumentDetails{},
+				},
+			},
+		},
+		{
+			Name:        "ping",
+			Description: "Pings.",
+			Arguments:   []ArgumentDetails{},
+		},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
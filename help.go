@@ -0,0 +1,244 @@
+package parsley
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Limits imposed by Discord on the contents of embeds.
+const (
+	_EmbedTitleLimit       = 256
+	_EmbedDescriptionLimit = 4096
+	_EmbedFieldCountLimit  = 25
+	_EmbedFieldNameLimit   = 256
+	_EmbedFieldValueLimit  = 1024
+	_EmbedTotalLimit       = 6000
+)
+
+// _HelpArgs represents the arguments accepted by the built-in help command.
+type _HelpArgs struct {
+	Command string `default:"" rest:"true" description:"Command to show detailed usage for."`
+}
+
+// EnableHelp registers a built-in help command with the provided name.
+//
+// When run without arguments, the help command lists every registered command. When provided with the name of a command or group,
+// it shows detailed usage for that command instead. Output is sent as embeds, split across multiple messages if it exceeds Discord's limits.
+func (parser *Parser) EnableHelp(name string, options ...CommandOption) error {
+	return Register(parser, name, "Shows the available commands, or detailed usage for a command.", func(ctx *Context, args _HelpArgs) error {
+		if ctx.Session == nil {
+			return ErrSessionUnavailable
+		}
+
+		var embeds []*discordgo.MessageEmbed
+		if strings.TrimSpace(args.Command) == "" {
+			embeds = _RenderCommandList(_UsagePrefix(ctx.Prefix), name, parser.GetCommands())
+		} else {
+			command, err := parser.GetCommand(strings.TrimSpace(args.Command))
+			if err != nil {
+				return fmt.Errorf("unable to show help for %q: %w", strings.TrimSpace(args.Command), err)
+			}
+			embeds = _RenderCommandHelp(_UsagePrefix(ctx.Prefix), command, parser.flexibleOrder)
+		}
+
+		for _, embed := range embeds {
+			if _, err := ctx.Session.ChannelMessageSendEmbed(ctx.Message.ChannelID, embed); err != nil {
+				return fmt.Errorf("error sending help: %w", err)
+			}
+		}
+		return nil
+	}, options...)
+}
+
+// _RenderCommandList renders embeds listing each of the provided commands, including the commands within groups.
+func _RenderCommandList(prefix, helpName string, commands []CommandDetails) []*discordgo.MessageEmbed {
+	fields := make([]*discordgo.MessageEmbedField, 0, len(commands))
+
+	var addCommands func(commands []CommandDetails)
+	addCommands = func(commands []CommandDetails) {
+		for _, command := range commands {
+			if command.Subcommands != nil {
+				addCommands(command.Subcommands)
+				continue
+			}
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:  _CommandTitle(prefix, command),
+				Value: _DescriptionOrDefault(command.Description),
+			})
+		}
+	}
+	addCommands(commands)
+
+	return _PaginateEmbed("Commands", fmt.Sprintf("Run `%s%s <command>` for detailed usage of a command.", prefix, helpName), fields)
+}
+
+// _RenderCommandHelp renders embeds showing detailed usage of an individual command or group.
+// flexibleOrdering determines whether arguments are described as being provided by name anywhere, or only after all other arguments.
+func _RenderCommandHelp(prefix string, command CommandDetails, flexibleOrdering bool) []*discordgo.MessageEmbed {
+	description := _DescriptionOrDefault(command.Description)
+
+	if command.Subcommands != nil {
+		fields := make([]*discordgo.MessageEmbedField, 0, len(command.Subcommands))
+		for _, subcommand := range command.Subcommands {
+			fields = append(fields, &discordgo.MessageEmbedField{
+				Name:  _CommandTitle(prefix, subcommand),
+				Value: _DescriptionOrDefault(subcommand.Description),
+			})
+		}
+		return _PaginateEmbed(_CommandTitle(prefix, command), description, fields)
+	}
+
+	description += fmt.Sprintf("\n\n**Usage:** `%s`", command.Usage(prefix))
+	if len(command.Arguments) != 0 && flexibleOrdering {
+		description += "\nArguments are provided in the order shown, or by name as `Name=value` or `--name value` anywhere in the message."
+	} else if len(command.Arguments) != 0 {
+		description += "\nArguments are provided in the order shown, or by name as `Name=value` or `--name value` after all other arguments."
+	}
+	if command.UserPermissions != 0 {
+		description += fmt.Sprintf("\n**Required permissions:** %s", strings.Join(PermissionNames(command.UserPermissions), ", "))
+	}
+	if command.BotPermissions != 0 {
+		description += fmt.Sprintf("\n**Required bot permissions:** %s", strings.Join(PermissionNames(command.BotPermissions), ", "))
+	}
+	if restrictions := _RestrictionDescriptions(command); len(restrictions) != 0 {
+		description += fmt.Sprintf("\n**Restrictions:** %s", strings.Join(restrictions, ", "))
+	}
+
+	fields := make([]*discordgo.MessageEmbedField, 0, len(command.Arguments))
+	for index, arg := range command.Arguments {
+		value := arg.Description
+		if arg.Required {
+			value += "\nRequired."
+		} else if arg.Default == "" {
+			value += "\nDefault: none"
+		} else {
+			value += fmt.Sprintf("\nDefault: `%s`", arg.Default)
+		}
+		value += fmt.Sprintf("\nKeyword: `%s=value`", arg.Name)
+		for _, alias := range arg.Aliases {
+			value += fmt.Sprintf(", `%s=value`", alias)
+		}
+		value += "\nFlag: " + _FlagUsage(arg)
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:  fmt.Sprintf("%d. %s (%s)", index+1, arg.Name, arg.Type),
+			Value: value,
+		})
+	}
+
+	return _PaginateEmbed(_CommandTitle(prefix, command), description, fields)
+}
+
+// _RestrictionDescriptions describes where, and by whom, the command is allowed to be run.
+func _RestrictionDescriptions(command CommandDetails) []string {
+	restrictions := make([]string, 0)
+	if command.GuildOnly {
+		restrictions = append(restrictions, "Servers only")
+	}
+	if command.DMOnly {
+		restrictions = append(restrictions, "Direct messages only")
+	}
+	if command.OwnerOnly {
+		restrictions = append(restrictions, "Bot owners only")
+	}
+	if command.NSFWOnly {
+		restrictions = append(restrictions, "Age-restricted channels only")
+	}
+	if len(command.AllowedChannels) != 0 {
+		channels := make([]string, len(command.AllowedChannels))
+		for index, id := range command.AllowedChannels {
+			channels[index] = fmt.Sprintf("<#%s>", id)
+		}
+		restrictions = append(restrictions, "Only in "+strings.Join(channels, " "))
+	}
+	if len(command.AllowedRoles) != 0 {
+		roles := make([]string, len(command.AllowedRoles))
+		for index, id := range command.AllowedRoles {
+			roles[index] = fmt.Sprintf("<@&%s>", id)
+		}
+		restrictions = append(restrictions, "Only for "+strings.Join(roles, " "))
+	}
+	return restrictions
+}
+
+// _CommandTitle returns the name of a command along with its prefix and aliases.
+func _CommandTitle(prefix string, command CommandDetails) string {
+	title := prefix + command.Name
+	if len(command.Aliases) != 0 {
+		title += fmt.Sprintf(" (aliases: %s)", strings.Join(command.Aliases, ", "))
+	}
+	return title
+}
+
+func _DescriptionOrDefault(description string) string {
+	if description == "" {
+		return "No description provided."
+	}
+	return description
+}
+
+// _PaginateEmbed splits the provided fields across as many embeds as required to stay within Discord's embed limits.
+// The description is only included in the first embed, and each embed is given a page number when more than one is required.
+func _PaginateEmbed(title, description string, fields []*discordgo.MessageEmbedField) []*discordgo.MessageEmbed {
+	title = _Truncate(title, _EmbedTitleLimit)
+	description = _Truncate(description, _EmbedDescriptionLimit)
+	// Leave room for the page footer, which is only known once pagination is complete.
+	const footerAllowance = 32
+
+	current := &discordgo.MessageEmbed{Title: title, Description: description}
+	currentSize := utf8.RuneCountInString(title) + utf8.RuneCountInString(description) + footerAllowance
+	embeds := []*discordgo.MessageEmbed{current}
+
+	for _, field := range fields {
+		field = &discordgo.MessageEmbedField{
+			Name:   _Truncate(field.Name, _EmbedFieldNameLimit),
+			Value:  _Truncate(field.Value, _EmbedFieldValueLimit),
+			Inline: field.Inline,
+		}
+		fieldSize := utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+
+		if len(current.Fields) == _EmbedFieldCountLimit || currentSize+fieldSize > _EmbedTotalLimit {
+			current = &discordgo.MessageEmbed{Title: title}
+			currentSize = utf8.RuneCountInString(title) + footerAllowance
+			embeds = append(embeds, current)
+		}
+
+		current.Fields = append(current.Fields, field)
+		currentSize += fieldSize
+	}
+
+	if len(embeds) > 1 {
+		for index, embed := range embeds {
+			embed.Footer = &discordgo.MessageEmbedFooter{
+				Text: fmt.Sprintf("Page %d of %d", index+1, len(embeds)),
+			}
+		}
+	}
+
+	return embeds
+}
+
+// _Truncate shortens the provided string to at most limit characters, marking it with an ellipsis if it was shortened.
+func _Truncate(value string, limit int) string {
+	if utf8.RuneCountInString(value) <= limit {
+		return value
+	}
+	runes := []rune(value)
+	return string(runes[:limit-1]) + "…"
+}
+
+// _FlagUsage describes how the provided argument can be given as a flag, such as `--reason value` or `-r value`.
+func _FlagUsage(arg ArgumentDetails) string {
+	suffix := " value"
+	if arg.Boolean {
+		suffix = ""
+	}
+	usage := fmt.Sprintf("`--%s%s`", arg.Flag, suffix)
+	if arg.Short != "" {
+		usage += fmt.Sprintf(", `-%s%s`", arg.Short, suffix)
+	}
+	return usage
+}
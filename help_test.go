@@ -0,0 +1,175 @@
+package parsley
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func _EmbedSize(embed *discordgo.MessageEmbed) int {
+	size := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	for _, field := range embed.Fields {
+		size += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	if embed.Footer != nil {
+		size += utf8.RuneCountInString(embed.Footer.Text)
+	}
+	return size
+}
+
+func TestHelpWithoutArguments(t *testing.T) {
+	parser := New("!")
+	parser.EnableHelp("help", WithAliases("h"))
+	parser.NewCommand("ping", "Pings.", func(message *discordgo.MessageCreate, args struct{}) {})
+	group, _ := parser.NewGroup("config", "")
+	group.NewCommand("set", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	transport := _SendingTransport()
+	session := _TestSession(t, transport)
+	err := parser.Execute(context.Background(), session, &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!h", ChannelID: "1"}})
+	if err != nil {
+		t.Fatalf("running command returned unexpected error: %s", err)
+	}
+
+	if len(transport.messages) != 1 {
+		t.Fatalf("help sent %d messages, expected 1", len(transport.messages))
+	}
+	if diff := deep.Equal(transport.messages[0].Embeds, []*discordgo.MessageEmbed{
+		{
+			Type:        discordgo.EmbedTypeRich,
+			Title:       "Commands",
+			Description: "Run `!help <command>` for detailed usage of a command.",
+			Fields: []*discordgo.MessageEmbedField{
+				{Name: "!config set", Value: "No description provided."},
+				{Name: "!help (aliases: h)", Value: "Shows the available commands, or detailed usage for a command."},
+				{Name: "!ping", Value: "Pings."},
+			},
+		},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestHelpWithCommand(t *testing.T) {
+	parser := New("!")
+	parser.EnableHelp("help")
+	group, _ := parser.NewGroup("config", "")
+	group.NewCommand("set", "Sets a value.", func(message *discordgo.MessageCreate, args struct {
+		Key   string `description:"Key to set."`
+		Value int    `default:"1"`
+	}) {
+	})
+
+	transport := _SendingTransport()
+	session := _TestSession(t, transport)
+	err := parser.Execute(context.Background(), session, &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!help config set", ChannelID: "1"}})
+	if err != nil {
+		t.Fatalf("running command returned unexpected error: %s", err)
+	}
+
+	if len(transport.messages) != 1 {
+		t.Fatalf("help sent %d messages, expected 1", len(transport.messages))
+	}
+	if diff := deep.Equal(transport.messages[0].Embeds, []*discordgo.MessageEmbed{
+		{
+			Type:  discordgo.EmbedTypeRich,
+			Title: "!config set",
+			Description: "Sets a value.\n\n**Usage:** `!config set <Key> [Value=1]`\n" +
+				"Arguments are provided in the order shown, or by name as `Name=value` or `--name value` after all other arguments.",
+			Fields: []*discordgo.MessageEmbedField{
+				{Name: "1. Key (string)", Value: "Key to set.\nRequired.\nKeyword: `Key=value`\nFlag: `--key value`"},
+				{Name: "2. Value (int)", Value: "No description provided.\nDefault: `1`\nKeyword: `Value=value`\nFlag: `--value value`"},
+			},
+		},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestHelpWithEmptyDefault(t *testing.T) {
+	parser := New("!")
+	parser.EnableHelp("help")
+
+	command, _ := parser.GetCommand("help")
+	embeds := _RenderCommandHelp("!", command, false)
+	if value := embeds[0].Fields[0].Value; !strings.Contains(value, "\nDefault: none\n") {
+		t.Errorf("help showed incorrect default in %q", value)
+	}
+}
+
+func TestHelpWithUnknownCommand(t *testing.T) {
+	parser := New("!")
+	parser.EnableHelp("help")
+
+	session := _TestSession(t, _SendingTransport())
+	err := parser.Execute(context.Background(), session, &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!help unknown"}})
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestHelpWithoutSession(t *testing.T) {
+	parser := New("!")
+	parser.EnableHelp("help")
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!help"}})
+	if !errors.Is(err, ErrSessionUnavailable) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestHelpWithManyCommands(t *testing.T) {
+	parser := New("!")
+	parser.EnableHelp("help")
+	for index := 0; index < 60; index++ {
+		parser.NewCommand(fmt.Sprintf("command%02d", index), strings.Repeat("a", 200), func(message *discordgo.MessageCreate, args struct{}) {})
+	}
+
+	transport := _SendingTransport()
+	session := _TestSession(t, transport)
+	err := parser.Execute(context.Background(), session, &discordgo.MessageCreate{Message: &discordgo.Message{Content: "!help", ChannelID: "1"}})
+	if err != nil {
+		t.Fatalf("running command returned unexpected error: %s", err)
+	}
+
+	fieldCount := 0
+	for index, message := range transport.messages {
+		embed := message.Embeds[0]
+		fieldCount += len(embed.Fields)
+		if len(embed.Fields) > _EmbedFieldCountLimit || _EmbedSize(embed) > _EmbedTotalLimit {
+			t.Errorf("page %d exceeds embed limits", index+1)
+		}
+		if embed.Footer == nil || embed.Footer.Text != fmt.Sprintf("Page %d of %d", index+1, len(transport.messages)) {
+			t.Errorf("page %d has incorrect footer", index+1)
+		}
+	}
+	if len(transport.messages) < 3 {
+		t.Errorf("help was not paginated")
+	}
+	if fieldCount != 61 {
+		t.Errorf("help listed %d commands, expected 61", fieldCount)
+	}
+}
+
+func TestPaginateEmbedTruncatesLongFields(t *testing.T) {
+	embeds := _PaginateEmbed("Title", "", []*discordgo.MessageEmbedField{
+		{Name: strings.Repeat("a", 300), Value: strings.Repeat("b", 2000)},
+	})
+
+	if len(embeds) != 1 {
+		t.Fatalf("paginating returned %d embeds, expected 1", len(embeds))
+	}
+	field := embeds[0].Fields[0]
+	if utf8.RuneCountInString(field.Name) != _EmbedFieldNameLimit || utf8.RuneCountInString(field.Value) != _EmbedFieldValueLimit {
+		t.Errorf("field was not truncated to embed limits")
+	}
+	if !strings.HasSuffix(field.Value, "…") {
+		t.Errorf("truncated field was not marked as truncated")
+	}
+}
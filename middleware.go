@@ -0,0 +1,16 @@
+package parsley
+
+// HandlerFunc represents a step in running a command, receiving the command's context and its parsed arguments struct.
+type HandlerFunc func(ctx *Context, args interface{}) error
+
+// Middleware wraps the running of a command, such as to log commands or enforce additional checks.
+//
+// Middleware is run after the command's arguments have been parsed, and can prevent the command from running
+// by returning an error without calling next.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Use registers middleware that wraps every command registered with the parser, including those within groups.
+// Middleware runs in the order it is registered, with parser middleware running before group and command middleware.
+func (parser *Parser) Use(middleware ...Middleware) {
+	parser.middleware = append(parser.middleware, middleware...)
+}
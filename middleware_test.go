@@ -0,0 +1,103 @@
+package parsley
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func _RecordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context, args interface{}) error {
+			*calls = append(*calls, name)
+			return next(ctx, args)
+		}
+	}
+}
+
+func TestRunCommandWithMiddlewareOrder(t *testing.T) {
+	var calls []string
+
+	parser := New(".")
+	parser.Use(_RecordingMiddleware("parser", &calls))
+	group, _ := parser.NewGroup("config", "")
+	group.Use(_RecordingMiddleware("group", &calls))
+	nestedGroup, _ := group.NewGroup("channel", "")
+	nestedGroup.Use(_RecordingMiddleware("nested group", &calls))
+	nestedGroup.NewCommand("set", "", func(message *discordgo.MessageCreate, args struct{}) {
+		calls = append(calls, "handler")
+	}, WithMiddleware(_RecordingMiddleware("command", &calls)))
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".config channel set"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+
+	if diff := deep.Equal(calls, []string{"parser", "group", "nested group", "command", "handler"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithMiddlewareReceivingArgs(t *testing.T) {
+	type testArgs struct {
+		Value int
+	}
+
+	parser := New(".")
+	called := false
+	parser.Use(func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context, args interface{}) error {
+			called = true
+			if ctx.Command != "test" {
+				t.Errorf("middleware was passed incorrect command name %s", ctx.Command)
+			}
+			if args.(testArgs).Value != 5 {
+				t.Errorf("middleware was not passed correct args")
+			}
+			return next(ctx, args)
+		}
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args testArgs) {})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test 5"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+	if !called {
+		t.Errorf("middleware was not called")
+	}
+}
+
+func TestRunCommandWithShortCircuitingMiddleware(t *testing.T) {
+	errDenied := errors.New("denied")
+
+	parser := New(".")
+	parser.Use(func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context, args interface{}) error {
+			return errDenied
+		}
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		t.Errorf("handler was called")
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}})
+	if !errors.Is(err, errDenied) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestRunCommandWithCommandMiddlewareOnlyAppliesToCommand(t *testing.T) {
+	var calls []string
+
+	parser := New(".")
+	parser.NewCommand("first", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithMiddleware(_RecordingMiddleware("first", &calls)))
+	parser.NewCommand("second", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".second"}})
+	if len(calls) != 0 {
+		t.Errorf("command middleware was run for another command")
+	}
+}
@@ -0,0 +1,88 @@
+package parsley
+
+import "time"
+
+// CommandOption configures an individual command when it is registered.
+type CommandOption func(command *Command)
+
+// WithAliases registers additional names the command can be invoked with.
+func WithAliases(aliases ...string) CommandOption {
+	return func(command *Command) {
+		command.aliases = append(command.aliases, aliases...)
+	}
+}
+
+// WithMiddleware registers middleware that wraps only this command.
+// Command middleware runs inside any middleware registered on the parser or on the groups containing the command.
+func WithMiddleware(middleware ...Middleware) CommandOption {
+	return func(command *Command) {
+		command.middleware = append(command.middleware, middleware...)
+	}
+}
+
+// WithUserPermissions requires the user running the command to have the provided permissions in the channel it is run in.
+// Permissions are combined using bitwise OR, such as discordgo.PermissionBanMembers|discordgo.PermissionKickMembers.
+func WithUserPermissions(permissions int64) CommandOption {
+	return func(command *Command) {
+		command.userPermissions |= permissions
+	}
+}
+
+// WithBotPermissions requires the bot to have the provided permissions in the channel the command is run in.
+// Permissions are combined using bitwise OR, such as discordgo.PermissionManageMessages|discordgo.PermissionEmbedLinks.
+func WithBotPermissions(permissions int64) CommandOption {
+	return func(command *Command) {
+		command.botPermissions |= permissions
+	}
+}
+
+// WithCooldown limits the command to the provided number of uses per duration within each bucket, such as 1 use per 10 seconds per user.
+// Uses must be at least 1 and per must be positive, otherwise registering the command fails with ErrInvalidCooldown.
+// Uses are counted before the command's arguments are parsed. Registering a cooldown replaces any cooldown previously registered for the command.
+func WithCooldown(uses int, per time.Duration, bucket CooldownBucket) CommandOption {
+	return func(command *Command) {
+		command.cooldown = &_Cooldown{uses, per, bucket}
+	}
+}
+
+// WithGuildOnly only allows the command to be run within guilds.
+func WithGuildOnly() CommandOption {
+	return func(command *Command) {
+		command.guildOnly = true
+	}
+}
+
+// WithDMOnly only allows the command to be run within direct messages.
+func WithDMOnly() CommandOption {
+	return func(command *Command) {
+		command.dmOnly = true
+	}
+}
+
+// WithOwnerOnly only allows the command to be run by the owners of the bot, as set using Parser.SetOwners.
+func WithOwnerOnly() CommandOption {
+	return func(command *Command) {
+		command.ownerOnly = true
+	}
+}
+
+// WithNSFWOnly only allows the command to be run within age-restricted channels.
+func WithNSFWOnly() CommandOption {
+	return func(command *Command) {
+		command.nsfwOnly = true
+	}
+}
+
+// WithAllowedChannels only allows the command to be run within the channels with the provided IDs.
+func WithAllowedChannels(ids ...string) CommandOption {
+	return func(command *Command) {
+		command.allowedChannels = append(command.allowedChannels, ids...)
+	}
+}
+
+// WithAllowedRoles only allows the command to be run by members holding at least one of the roles with the provided IDs.
+func WithAllowedRoles(ids ...string) CommandOption {
+	return func(command *Command) {
+		command.allowedRoles = append(command.allowedRoles, ids...)
+	}
+}
@@ -0,0 +1,135 @@
+package parsley
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestRunCommandWithAlias(t *testing.T) {
+	parser := New(".")
+	called := 0
+	parser.NewCommand("help", "", func(ctx *Context, args struct{}) {
+		called++
+		if ctx.Command != "help" {
+			t.Errorf("handler was passed incorrect command name %s", ctx.Command)
+		}
+	}, WithAliases("h", "commands"))
+
+	for _, content := range []string{".help", ".h", ".commands"} {
+		err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: content}})
+		if err != nil {
+			t.Errorf("running command returned unexpected error")
+		}
+	}
+	if called != 3 {
+		t.Errorf("handler was called %d times, expected 3", called)
+	}
+}
+
+func TestRunCommandWithSubcommandAlias(t *testing.T) {
+	parser := New(".")
+	group, _ := parser.NewGroup("config", "")
+	called := false
+	group.NewCommand("set", "", func(ctx *Context, args struct{}) {
+		called = true
+		if ctx.Command != "config set" {
+			t.Errorf("handler was passed incorrect command name %s", ctx.Command)
+		}
+	}, WithAliases("s"))
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".config s"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestNewCommandWithAliasConflictingWithName(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("h", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	err := parser.NewCommand("help", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithAliases("h"))
+	if !errors.Is(err, ErrCommandNameConflict) {
+		t.Errorf("adding command did not return correct error")
+	}
+	if _, err := parser.GetCommand("help"); err == nil {
+		t.Errorf("conflicting command was registered")
+	}
+}
+
+func TestNewCommandWithNameConflictingWithAlias(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("help", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithAliases("h"))
+
+	err := parser.NewCommand("h", "", func(message *discordgo.MessageCreate, args struct{}) {})
+	if !errors.Is(err, ErrCommandNameConflict) {
+		t.Errorf("adding command did not return correct error")
+	}
+}
+
+func TestNewCommandWithDuplicateName(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("help", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	err := parser.NewCommand("help", "", func(message *discordgo.MessageCreate, args struct{}) {})
+	if !errors.Is(err, ErrCommandNameConflict) {
+		t.Errorf("adding command did not return correct error")
+	}
+}
+
+func TestNewCommandWithDuplicateAlias(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("help", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithAliases("h", "h"))
+	if !errors.Is(err, ErrCommandNameConflict) {
+		t.Errorf("adding command did not return correct error")
+	}
+}
+
+func TestNewGroupWithNameConflictingWithAlias(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("configure", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithAliases("config"))
+
+	_, err := parser.NewGroup("config", "")
+	if !errors.Is(err, ErrCommandNameConflict) {
+		t.Errorf("adding group did not return correct error")
+	}
+}
+
+func TestGetCommandWithAlias(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("help", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithAliases("h", "commands"))
+
+	command, err := parser.GetCommand("h")
+	if err != nil {
+		t.Errorf("got unexpected error")
+	}
+
+	if diff := deep.Equal(command, CommandDetails{
+		Name:      "help",
+		Aliases:   []string{"h", "commands"},
+		Arguments: []ArgumentDetails{},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestGetCommandsWithAliases(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("help", "", func(message *discordgo.MessageCreate, args struct{}) {}, WithAliases("h", "commands"))
+
+	if diff := deep.Equal(parser.GetCommands(), []CommandDetails{
+		{
+			Name:      "help",
+			Aliases:   []string{"h", "commands"},
+			Arguments: []ArgumentDetails{},
+		},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
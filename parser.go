@@ -1,276 +1,556 @@
 package parsley
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"reflect"
-	"regexp"
+	"runtime/debug"
 	"sort"
-	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/bwmarrin/discordgo"
-	"github.com/google/shlex"
 )
 
-var _KwargPattern = regexp.MustCompile(`^([a-zA-Z_\d]+)=(.*)$`)
+var _ErrorType = reflect.TypeOf((*error)(nil)).Elem()
 
-// Command represents an individual Discord command.
+var _ContextType = reflect.TypeOf(&Context{})
+
+// Command represents an individual Discord command, or a group of subcommands.
 type Command struct {
+	key         string
+	name        string
+	aliases     []string
 	description string
-	handler     interface{}
+	plan        *_BindingPlan
+	handler     func(ctx *Context, args reflect.Value) error
+	middleware  []Middleware
+	subcommands map[string]*Command
+
+	userPermissions int64
+	botPermissions  int64
+	cooldown        *_Cooldown
+
+	guildOnly       bool
+	dmOnly          bool
+	ownerOnly       bool
+	nsfwOnly        bool
+	allowedChannels []string
+	allowedRoles    []string
 }
 
 // ArgumentDetails represents the details of an individual command argument.
+// Variadic arguments consume all remaining positional arguments, while Rest arguments capture the remainder of the message.
+// Aliases contains any additional names the argument can be provided with.
+// Flag and Short contain the names the argument can be provided with as --flag or -s, with Short being empty if the argument has no short flag.
+// Boolean flags do not require a value, and default to false when they have no default tag.
 type ArgumentDetails struct {
 	Name        string
+	Aliases     []string
 	Type        string
 	Description string
 	Required    bool
 	Default     string
+	Variadic    bool
+	Rest        bool
+	Flag        string
+	Short       string
+	Boolean     bool
 }
 
 // CommandDetails represents the parsed details of an individual command.
+// For command groups, Subcommands contains the details of each command within the group.
+// UserPermissions and BotPermissions contain the permissions required by the user running the command and by the bot, respectively.
+// The remaining fields describe where and by whom the command is allowed to be run.
 type CommandDetails struct {
-	Name        string
-	Description string
-	Arguments   []ArgumentDetails
+	Name            string
+	Aliases         []string
+	Description     string
+	Arguments       []ArgumentDetails
+	Subcommands     []CommandDetails
+	UserPermissions int64
+	BotPermissions  int64
+	GuildOnly       bool
+	DMOnly          bool
+	OwnerOnly       bool
+	NSFWOnly        bool
+	AllowedChannels []string
+	AllowedRoles    []string
 }
 
 // Parser represents a parser for Discord commands.
 type Parser struct {
-	prefix   string
-	commands map[string]Command
+	prefixes       []string
+	prefixResolver PrefixResolver
+	mentionPrefix  bool
+	commands       map[string]*Command
+	converters     map[reflect.Type]ConverterFunc
+	errorHandler   ErrorHandler
+	repanic        bool
+	middleware     []Middleware
+	cooldowns      CooldownStore
+	owners         []string
+	tokenizer      Tokenizer
+	foldKeywords   bool
+	flexibleOrder  bool
+}
+
+// Registrar represents something commands can be registered with, such as a Parser or a Group.
+type Registrar interface {
+	NewCommand(name, description string, handler interface{}, options ...CommandOption) error
+	NewGroup(name, description string) (*Group, error)
+
+	addCommand(name, description string, argsType reflect.Type, handler func(ctx *Context, args reflect.Value) error, options []CommandOption) error
 }
 
 // NewCommand registers a new command with the command parser.
-func (parser *Parser) NewCommand(name, description string, handler interface{}) error {
+func (parser *Parser) NewCommand(name, description string, handler interface{}, options ...CommandOption) error {
+	return _NewCommand(parser, name, description, handler, options)
+}
+
+// NewGroup registers a new group of subcommands with the command parser.
+// If a group with the provided name already exists, it is returned instead.
+func (parser *Parser) NewGroup(name, description string) (*Group, error) {
+	return parser.registerGroup(parser.commands, "", name, description)
+}
+
+func _NewCommand(registrar Registrar, name, description string, handler interface{}, options []CommandOption) error {
 	err := _ValidateHandler(handler)
 	if err != nil {
 		return fmt.Errorf("invalid command handler: %w", err)
 	}
-	command := Command{description, handler}
-	parser.commands[name] = command
+
+	handlerValue := reflect.ValueOf(handler)
+	passContext := handlerValue.Type().In(0) == _ContextType
+
+	return registrar.addCommand(name, description, handlerValue.Type().In(1), func(ctx *Context, args reflect.Value) error {
+		firstParamValue := reflect.ValueOf(ctx.Message)
+		if passContext {
+			firstParamValue = reflect.ValueOf(ctx)
+		}
+
+		results := handlerValue.Call([]reflect.Value{firstParamValue, args})
+		if len(results) == 1 && !results[0].IsNil() {
+			return results[0].Interface().(error)
+		}
+		return nil
+	}, options)
+}
+
+// Register registers a new command with a parser or group, using the type of the handler's second parameter as the command's arguments.
+// Unlike NewCommand, mistakes in the handler's signature are caught at compile time.
+func Register[Args any](registrar Registrar, name, description string, handler func(ctx *Context, args Args) error, options ...CommandOption) error {
+	argsType := reflect.TypeOf((*Args)(nil)).Elem()
+	if argsType.Kind() != reflect.Struct {
+		return fmt.Errorf("invalid command handler: %w", ErrHandlerInvalidSecondParameterType)
+	}
+
+	return registrar.addCommand(name, description, argsType, func(ctx *Context, args reflect.Value) error {
+		return handler(ctx, args.Interface().(Args))
+	}, options)
+}
+
+func (parser *Parser) addCommand(
+	name, description string,
+	argsType reflect.Type,
+	handler func(ctx *Context, args reflect.Value) error,
+	options []CommandOption,
+) error {
+	return parser.registerCommand(parser.commands, "", name, description, argsType, handler, options)
+}
+
+func (parser *Parser) registerCommand(
+	commands map[string]*Command,
+	parentName, name, description string,
+	argsType reflect.Type,
+	handler func(ctx *Context, args reflect.Value) error,
+	options []CommandOption,
+) error {
+	plan, err := _NewBindingPlan(argsType, parser.converters)
+	if err != nil {
+		return fmt.Errorf("invalid command arguments: %w", err)
+	}
+
+	command := &Command{
+		key:         name,
+		name:        _JoinCommandName(parentName, name),
+		description: description,
+		plan:        plan,
+		handler:     handler,
+	}
+	for _, option := range options {
+		option(command)
+	}
+	if command.cooldown != nil && (command.cooldown.uses <= 0 || command.cooldown.per <= 0) {
+		return fmt.Errorf("unable to register command %s: %w", command.name, ErrInvalidCooldown)
+	}
+
+	keys := append([]string{name}, command.aliases...)
+	for index, key := range keys {
+		if existing, found := commands[key]; found {
+			return fmt.Errorf("unable to register command %s: %q is already used by %s: %w", command.name, key, existing.name, ErrCommandNameConflict)
+		}
+		for _, previousKey := range keys[:index] {
+			if previousKey == key {
+				return fmt.Errorf("unable to register command %s: %q is used more than once: %w", command.name, key, ErrCommandNameConflict)
+			}
+		}
+	}
+	for _, key := range keys {
+		commands[key] = command
+	}
 
 	return nil
 }
 
+func (parser *Parser) registerGroup(commands map[string]*Command, parentName, name, description string) (*Group, error) {
+	existing, found := commands[name]
+	if found && (existing.subcommands == nil || existing.key != name) {
+		return nil, fmt.Errorf("unable to register group %s: %q is already used by %s: %w", name, name, existing.name, ErrCommandNameConflict)
+	}
+	if !found {
+		existing = &Command{
+			key:         name,
+			name:        _JoinCommandName(parentName, name),
+			description: description,
+			subcommands: make(map[string]*Command),
+		}
+		commands[name] = existing
+	}
+
+	return &Group{parser, existing}, nil
+}
+
 // RunCommand parses the content of a specific message and runs the associated command, if found.
+// Any error returned by the command's handler is wrapped and returned.
+//
+// Handlers expecting a *Context will receive one without a session. Use Execute to provide one.
 func (parser *Parser) RunCommand(message *discordgo.MessageCreate) error {
-	if !strings.HasPrefix(message.Content, parser.prefix) {
+	return parser.Execute(context.Background(), nil, message)
+}
+
+// Execute parses the content of a message received on the given session and runs the associated command, if found.
+// The *Context passed to the command's handler is derived from ctx, and is cancelled once the handler returns.
+func (parser *Parser) Execute(ctx context.Context, session *discordgo.Session, message *discordgo.MessageCreate) error {
+	commandCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	return parser.run(&Context{Context: commandCtx, Session: session, Message: message})
+}
+
+// run parses the message within the provided context and runs the command it refers to.
+// The context's Command and Arguments are populated once the command has been resolved.
+// Panics that occur while running the command are returned as a *PanicError, unless the parser is set to re-panic.
+func (parser *Parser) run(ctx *Context) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			if parser.repanic {
+				panic(value)
+			}
+			err = &PanicError{Command: ctx.Command, Value: value, Stack: debug.Stack()}
+		}
+	}()
+
+	prefix, mention, ok := parser.matchPrefix(ctx)
+	if !ok {
 		return nil
 	}
+	ctx.Prefix = prefix
 
-	arguments, err := shlex.Split(message.Content)
-	arguments[0] = strings.TrimPrefix(arguments[0], parser.prefix)
+	content := ctx.Message.Content[len(prefix):]
+	if mention {
+		content = strings.TrimLeftFunc(content, unicode.IsSpace)
+	}
+
+	tokens, err := _NewTokenStream(parser.tokenizer, content)
 	if err != nil {
 		return fmt.Errorf("error parsing arguments: %w", err)
 	}
 
-	command, ok := parser.commands[arguments[0]]
+	// The command name must immediately follow the prefix, unless the prefix is a mention of the bot.
+	commandName := ""
+	token, ok, err := tokens.peek(0)
+	if err != nil {
+		return fmt.Errorf("error parsing arguments: %w", err)
+	}
+	if ok && token.Start == 0 {
+		commandName = token.Value
+		tokens.advance(1)
+	}
+
+	command, ok := parser.commands[commandName]
 	if !ok {
 		return fmt.Errorf("error running command: %w", ErrUnknownCommand)
 	}
+	middleware := append(append([]Middleware{}, parser.middleware...), command.middleware...)
+	for command.subcommands != nil {
+		token, hasToken, err := tokens.peek(0)
+		if err != nil {
+			return fmt.Errorf("error parsing arguments: %w", err)
+		}
 
-	argsParamType := reflect.TypeOf(command.handler).In(1)
-	argsParamValue := reflect.New(argsParamType).Elem()
+		subcommand, ok := command.subcommands[token.Value]
+		if !ok || !hasToken {
+			return fmt.Errorf("error running command: %w", &UnknownSubcommandError{
+				Group:       command.name,
+				Subcommand:  token.Value,
+				Subcommands: _SortedCommandNames(command.subcommands),
+			})
+		}
+		command = subcommand
+		middleware = append(middleware, command.middleware...)
+		tokens.advance(1)
+	}
 
-	commandArgNames := map[string]bool{}
+	ctx.Command = command.name
+	// Only the tokens following the command name are arguments.
+	tokens.consumed = tokens.consumed[:0]
 
-	for index := 0; index < argsParamValue.NumField(); index++ {
-		commandArgNames[argsParamType.Field(index).Name] = true
+	if err := parser.checkRestrictions(ctx, command); err != nil {
+		return fmt.Errorf("error running command: %w", err)
+	}
+	if err := _CheckPermissions(ctx, command); err != nil {
+		return fmt.Errorf("error running command: %w", err)
+	}
+	if err := parser.checkCooldown(ctx, command); err != nil {
+		return fmt.Errorf("error running command: %w", err)
 	}
 
-	kwargs := make(map[string]string)
-	nonKwargArgs := make([]string, 0)
-
-	parsingKwargs := false
-	for _, val := range arguments[1:] {
-		matches := _KwargPattern.FindStringSubmatch(val)
-		if len(matches) == 0 {
-			if parsingKwargs {
-				return fmt.Errorf("error running command: %w", ErrKwargsMustBeAtEnd)
-			}
-			nonKwargArgs = append(nonKwargArgs, val)
-			continue
-		}
-		_, isValidKwarg := commandArgNames[matches[1]]
-		if !isValidKwarg {
-			nonKwargArgs = append(nonKwargArgs, val)
-			continue
+	argsParamValue, err := command.plan.bind(ctx, tokens, _BindingOptions{
+		caseInsensitiveKeywords: parser.foldKeywords,
+		flexibleOrdering:        parser.flexibleOrder,
+	})
+	ctx.Arguments = tokens.consumed
+	if err != nil {
+		usage := _CommandDetails(command).Usage(_UsagePrefix(prefix))
+		if usageErr, ok := err.(_UsageError); ok {
+			usageErr.setUsage(usage)
+			return usageErr
 		}
-		kwargs[matches[1]] = matches[2]
-		parsingKwargs = true
+		return fmt.Errorf("error parsing arguments: %w\nUsage: %s", err, usage)
 	}
 
-	for index := 0; index < argsParamValue.NumField(); index++ {
-		field := argsParamValue.Field(index)
-		fieldType := argsParamType.Field(index)
-		var value string
+	handler := HandlerFunc(func(ctx *Context, args interface{}) error {
+		return command.handler(ctx, reflect.ValueOf(args))
+	})
+	for index := len(middleware) - 1; index >= 0; index-- {
+		handler = middleware[index](handler)
+	}
 
-		if kwargVal, found := kwargs[fieldType.Name]; found {
-			value = kwargVal
-		} else if index >= len(nonKwargArgs) {
-			defaultVal, ok := fieldType.Tag.Lookup("default")
-			if !ok {
-				return fmt.Errorf("error parsing arguments: %w", ErrRequiredArgumentMissing)
-			}
-			value = defaultVal
-		} else {
-			value = nonKwargArgs[index]
+	err = handler(ctx, argsParamValue.Interface())
+	if err != nil {
+		return fmt.Errorf("error running command: %w", err)
+	}
+
+	return nil
+}
+
+// SetMentionPrefix sets whether mentioning the bot, such as "@Bot ping", can be used as a prefix in addition to the parser's prefixes.
+// Mentions are only recognized when running commands with a session whose state contains the bot's user.
+func (parser *Parser) SetMentionPrefix(enabled bool) {
+	parser.mentionPrefix = enabled
+}
+
+// matchPrefix returns the longest prefix the context's message starts with, and whether that prefix is a mention of the bot.
+func (parser *Parser) matchPrefix(ctx *Context) (prefix string, mention, ok bool) {
+	content := ctx.Message.Content
+	prefixes := parser.prefixes
+	if parser.prefixResolver != nil {
+		prefixes = parser.prefixResolver.Prefixes(ctx, ctx.Message)
+	}
+	for _, candidate := range prefixes {
+		if strings.HasPrefix(content, candidate) && (!ok || len(candidate) > len(prefix)) {
+			prefix, ok = candidate, true
 		}
+	}
 
-		switch field.Kind() {
-		case reflect.Bool:
-			boolVal, err := strconv.ParseBool(value)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetBool(boolVal)
-		case reflect.Int, reflect.Int64:
-			intVal, err := strconv.Atoi(value)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetInt(int64(intVal))
-		case reflect.Int8:
-			intVal, err := strconv.ParseInt(value, 10, 8)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetInt(intVal)
-		case reflect.Int16:
-			intVal, err := strconv.ParseInt(value, 10, 16)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetInt(intVal)
-		case reflect.Int32:
-			intVal, err := strconv.ParseInt(value, 10, 32)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
+	if parser.mentionPrefix && ctx.Session != nil && ctx.Session.State != nil && ctx.Session.State.User != nil {
+		for _, candidate := range []string{"<@" + ctx.Session.State.User.ID + ">", "<@!" + ctx.Session.State.User.ID + ">"} {
+			if strings.HasPrefix(content, candidate) && (!ok || len(candidate) > len(prefix)) {
+				prefix, mention, ok = candidate, true, true
 			}
-			field.SetInt(intVal)
-		case reflect.Uint, reflect.Uint64:
-			intVal, err := strconv.ParseUint(value, 10, 64)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetUint(intVal)
-		case reflect.Uint8:
-			intVal, err := strconv.ParseUint(value, 10, 8)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetUint(intVal)
-		case reflect.Uint16:
-			intVal, err := strconv.ParseUint(value, 10, 16)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetUint(intVal)
-		case reflect.Uint32:
-			intVal, err := strconv.ParseUint(value, 10, 32)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetUint(intVal)
-		case reflect.Float32:
-			floatVal, err := strconv.ParseFloat(value, 32)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetFloat(floatVal)
-		case reflect.Float64:
-			floatVal, err := strconv.ParseFloat(value, 64)
-			if err != nil {
-				return fmt.Errorf("error parsing arguments: %w", err)
-			}
-			field.SetFloat(floatVal)
-		case reflect.String:
-			field.SetString(value)
 		}
 	}
 
-	reflect.ValueOf(command.handler).Call([]reflect.Value{reflect.ValueOf(message), argsParamValue})
+	return prefix, mention, ok
+}
 
-	return nil
+// primaryPrefix returns the first of the parser's prefixes, which is used when no prefix has been matched against a message.
+func (parser *Parser) primaryPrefix() string {
+	if len(parser.prefixes) == 0 {
+		return ""
+	}
+	return parser.prefixes[0]
+}
+
+// _UsagePrefix returns the provided prefix as it should be shown in usage, separating mentions from the command name.
+func _UsagePrefix(prefix string) string {
+	if _UserMentionPattern.MatchString(prefix) {
+		return prefix + " "
+	}
+	return prefix
+}
+
+// SetCaseInsensitiveKeywords sets whether keyword arguments are matched regardless of case, such as allowing reason=spam for an argument named Reason.
+// Flags are always matched exactly.
+func (parser *Parser) SetCaseInsensitiveKeywords(enabled bool) {
+	parser.foldKeywords = enabled
+}
+
+// SetFlexibleOrdering sets whether arguments provided by name, as keywords or flags, can appear anywhere in a message rather than only after
+// all positional arguments. Positional arguments fill the arguments that have not already been provided by name, in the order they are declared.
+func (parser *Parser) SetFlexibleOrdering(enabled bool) {
+	parser.flexibleOrder = enabled
+}
+
+// SetRepanic sets whether panics that occur while running commands are propagated to the caller, rather than being returned as a *PanicError.
+// This is primarily useful in tests, where panics should fail the test rather than being reported as errors.
+func (parser *Parser) SetRepanic(repanic bool) {
+	parser.repanic = repanic
 }
 
 // RegisterHandler registers a simpler handler on a discordgo session to automatically parse incoming messages for you.
+// Any errors that occur are passed to the parser's error handler, which can be changed using SetErrorHandler.
 func (parser *Parser) RegisterHandler(session *discordgo.Session) {
 	session.AddHandler(func(session *discordgo.Session, message *discordgo.MessageCreate) {
-		err := parser.RunCommand(message)
+		commandCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
 
-		if err != nil {
-			_, err = session.ChannelMessageSend(
-				message.ChannelID,
-				fmt.Sprintf("An error occurred running your command:\n```\n%s\n```", err.Error()),
-			)
-			if err != nil {
-				log.Fatalf("Failed to send error message: %s", err.Error())
-			}
+		ctx := &Context{Context: commandCtx, Session: session, Message: message}
+		if err := parser.run(ctx); err != nil {
+			parser.errorHandler(ctx, message, err)
 		}
 	})
 }
 
+// Usage returns a one-line synopsis of how to run the command with the provided name, such as "!ban <user> [reason=No reason]".
+func (parser *Parser) Usage(commandName string) (string, error) {
+	command, err := parser.GetCommand(commandName)
+	if err != nil {
+		return "", err
+	}
+	return command.Usage(_UsagePrefix(parser.primaryPrefix())), nil
+}
+
 // GetCommand retrieves the details of an individual command.
+// Subcommands can be retrieved by separating the names of their groups and the subcommand with spaces, such as "config set".
+// Aliases are resolved to the command they refer to.
 func (parser *Parser) GetCommand(commandName string) (CommandDetails, error) {
-	commandObj, found := parser.commands[commandName]
+	path := strings.Fields(commandName)
+	if len(path) == 0 {
+		path = []string{commandName}
+	}
 
-	if !found {
-		return CommandDetails{}, ErrUnknownCommand
+	commands := parser.commands
+	var commandObj *Command
+	for _, name := range path {
+		var found bool
+		commandObj, found = commands[name]
+		if !found {
+			return CommandDetails{}, ErrUnknownCommand
+		}
+		commands = commandObj.subcommands
 	}
 
-	commandDetailsObj := CommandDetails{
-		Name:        commandName,
-		Description: commandObj.description,
-		Arguments:   make([]ArgumentDetails, 0),
+	return _CommandDetails(commandObj), nil
+}
+
+// GetCommands parses all registered commands and returns details related to each of them.
+// Commands within groups are included in the Subcommands of their group.
+func (parser *Parser) GetCommands() []CommandDetails {
+	commandDetails := make([]CommandDetails, 0)
+
+	for _, commandName := range _SortedCommandNames(parser.commands) {
+		commandDetails = append(commandDetails, _CommandDetails(parser.commands[commandName]))
 	}
 
-	argsType := reflect.TypeOf(commandObj.handler).In(1)
+	return commandDetails
+}
 
-	for index := 0; index < argsType.NumField(); index++ {
-		arg := argsType.Field(index)
+func _CommandDetails(command *Command) CommandDetails {
+	commandDetailsObj := CommandDetails{
+		Name:            command.name,
+		Aliases:         command.aliases,
+		Description:     command.description,
+		Arguments:       make([]ArgumentDetails, 0),
+		UserPermissions: command.userPermissions,
+		BotPermissions:  command.botPermissions,
+		GuildOnly:       command.guildOnly,
+		DMOnly:          command.dmOnly,
+		OwnerOnly:       command.ownerOnly,
+		NSFWOnly:        command.nsfwOnly,
+		AllowedChannels: command.allowedChannels,
+		AllowedRoles:    command.allowedRoles,
+	}
 
-		defaultVal, hasDefault := arg.Tag.Lookup("default")
-		description, hasDescription := arg.Tag.Lookup("description")
-		if !hasDescription {
-			description = "No description provided."
+	if command.subcommands != nil {
+		commandDetailsObj.Subcommands = make([]CommandDetails, 0, len(command.subcommands))
+		for _, subcommandName := range _SortedCommandNames(command.subcommands) {
+			commandDetailsObj.Subcommands = append(commandDetailsObj.Subcommands, _CommandDetails(command.subcommands[subcommandName]))
 		}
+		return commandDetailsObj
+	}
+
+	for _, arg := range command.plan.arguments {
 		commandDetailsObj.Arguments = append(commandDetailsObj.Arguments, ArgumentDetails{
-			Name:        arg.Name,
-			Type:        arg.Type.Name(),
-			Description: description,
-			Required:    !hasDefault,
-			Default:     defaultVal,
+			Name:        arg.name,
+			Aliases:     arg.aliases,
+			Type:        arg.typeName,
+			Description: arg.description,
+			Required:    !arg.hasDefault,
+			Default:     arg.rawDefault,
+			Variadic:    arg.variadic,
+			Rest:        arg.rest,
+			Flag:        arg.flag,
+			Short:       arg.short,
+			Boolean:     arg.boolean,
 		})
 	}
 
-	return commandDetailsObj, nil
+	return commandDetailsObj
 }
 
-// GetCommands parses all registered commands and returns details related to each of them.
-func (parser *Parser) GetCommands() []CommandDetails {
-	commandDetails := make([]CommandDetails, 0)
-	commands := make([]string, 0)
-	for command := range parser.commands {
-		commands = append(commands, command)
+// _SortedCommandNames returns the sorted names of the provided commands, excluding aliases.
+func _SortedCommandNames(commands map[string]*Command) []string {
+	names := make([]string, 0, len(commands))
+	for name, command := range commands {
+		if command.key == name {
+			names = append(names, name)
+		}
 	}
-	sort.Strings(commands)
+	sort.Strings(names)
 
-	for _, commandName := range commands {
-		commandInfo, _ := parser.GetCommand(commandName)
-		commandDetails = append(commandDetails, commandInfo)
-	}
+	return names
+}
 
-	return commandDetails
+func _JoinCommandName(parentName, name string) string {
+	if parentName == "" {
+		return name
+	}
+	return parentName + " " + name
 }
 
 // New creates a new Parsley parser.
-func New(prefix string) *Parser {
+//
+// The parser supports arguments of type *discordgo.User, *discordgo.Member, *discordgo.Channel, *discordgo.Role and *discordgo.Emoji,
+// accepting mentions, IDs or names and resolving them through the session's state before falling back to the REST API.
+// Standard Unicode emoji, such as 👍, are accepted as-is. An empty value resolves to nil, so entity arguments tagged with default:"" are optional.
+//
+// Messages must start with one of the provided prefixes to be treated as commands. When more than one prefix matches a message,
+// the longest is used.
+func New(prefixes ...string) *Parser {
+	converters := make(map[reflect.Type]ConverterFunc, len(_EntityConverters))
+	for argType, converter := range _EntityConverters {
+		converters[argType] = converter
+	}
+
 	return &Parser{
-		prefix, make(map[string]Command, 0),
+		prefixes:     append([]string(nil), prefixes...),
+		commands:     make(map[string]*Command, 0),
+		converters:   converters,
+		errorHandler: DefaultErrorHandler,
+		cooldowns:    NewMemoryCooldownStore(),
+		tokenizer:    DiscordTokenizer{},
 	}
 }
 
@@ -283,11 +563,14 @@ func _ValidateHandler(handler interface{}) error {
 		return ErrHandlerInvalidParameterCount
 	}
 	firstParam := handlerType.In(0)
-	if firstParam.Kind() != reflect.Ptr || firstParam.Elem() != reflect.TypeOf(discordgo.MessageCreate{}) {
+	if firstParam != _ContextType && (firstParam.Kind() != reflect.Ptr || firstParam.Elem() != reflect.TypeOf(discordgo.MessageCreate{})) {
 		return ErrHandlerInvalidFirstParameterType
 	}
 	if handlerType.In(1).Kind() != reflect.Struct {
 		return ErrHandlerInvalidSecondParameterType
 	}
+	if handlerType.NumOut() > 1 || (handlerType.NumOut() == 1 && handlerType.Out(0) != _ErrorType) {
+		return ErrHandlerInvalidReturnType
+	}
 	return nil
 }
@@ -1,7 +1,10 @@
 package parsley
 
 import (
+	"context"
 	"errors"
+	"reflect"
+	"runtime"
 	"strconv"
 	"testing"
 
@@ -11,8 +14,8 @@ import (
 
 func TestNewParser(t *testing.T) {
 	parser := New("test")
-	if parser.prefix != "test" {
-		t.Errorf("parser was returned with incorrect prefix %s", parser.prefix)
+	if diff := deep.Equal(parser.prefixes, []string{"test"}); diff != nil {
+		t.Errorf("parser was returned with incorrect prefixes %v", parser.prefixes)
 	}
 	if len(parser.commands) != 0 {
 		t.Error("parser was returned with non-empty initial command list")
@@ -64,6 +67,42 @@ func TestNewCommandWithInvalidSecondParameterType(t *testing.T) {
 	}
 }
 
+func TestNewCommandWithContextParameter(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(ctx *Context, b struct{}) error { return nil })
+	if err != nil {
+		t.Errorf("adding command returned unexpected error")
+	}
+}
+
+func TestNewCommandWithInvalidReturnType(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct{}) string { return "" })
+	if errors.Unwrap(err) != ErrHandlerInvalidReturnType {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithTooManyReturnValues(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct{}) (string, error) { return "", nil })
+	if errors.Unwrap(err) != ErrHandlerInvalidReturnType {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithErrorReturningHandler(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct{}) error { return nil })
+	if err != nil {
+		t.Errorf("adding command returned unexpected error")
+	}
+}
+
 func TestNewCommandWithValidData(t *testing.T) {
 	parser := New("")
 
@@ -73,6 +112,162 @@ func TestNewCommandWithValidData(t *testing.T) {
 	}
 }
 
+func TestNewCommandWithInvalidDefaultValue(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Arg int `default:"ABC"`
+	}) {
+	})
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithInvalidDefaultValueReportsField(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Arg float32 `default:"ABC"`
+	}) {
+	})
+	var defaultErr *InvalidDefaultValueError
+	if !errors.As(err, &defaultErr) {
+		t.Fatal("parser did not return correct error")
+	}
+	if defaultErr.Field != "Arg" || defaultErr.Type != reflect.TypeOf(float32(0)) || defaultErr.Value != "ABC" {
+		t.Errorf("error contained incorrect details: %s", defaultErr)
+	}
+}
+
+func TestNewCommandWithUnexportedArgument(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		arg string
+	}) {
+	})
+	var unexportedErr *UnexportedArgumentError
+	if !errors.As(err, &unexportedErr) {
+		t.Fatal("parser did not return correct error")
+	}
+	if unexportedErr.Field != "arg" {
+		t.Errorf("error contained incorrect field %s", unexportedErr.Field)
+	}
+}
+
+func TestNewCommandWithUnsupportedArgumentType(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Arg map[string]string
+	}) {
+	})
+	var unsupportedErr *UnsupportedArgumentTypeError
+	if !errors.As(err, &unsupportedErr) {
+		t.Fatal("parser did not return correct error")
+	}
+	if unsupportedErr.Field != "Arg" || unsupportedErr.Type != reflect.TypeOf(map[string]string{}) {
+		t.Errorf("error contained incorrect details: %s", unsupportedErr)
+	}
+}
+
+func TestRegisterWithUnsupportedArgumentType(t *testing.T) {
+	parser := New("")
+
+	err := Register(parser, "", "", func(ctx *Context, args struct {
+		Arg *string
+	}) error {
+		return nil
+	})
+	var unsupportedErr *UnsupportedArgumentTypeError
+	if !errors.As(err, &unsupportedErr) {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithSliceArgumentNotLast(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Args []string
+		Arg  string
+	}) {
+	})
+	if !errors.Is(err, ErrGreedyArgumentNotLast) {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithUnsupportedSliceArgument(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Args [][]string
+	}) {
+	})
+	var unsupportedErr *UnsupportedArgumentTypeError
+	if !errors.As(err, &unsupportedErr) {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithRestArgumentNotLast(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Rest string `rest:"true"`
+		Arg  string
+	}) {
+	})
+	if !errors.Is(err, ErrGreedyArgumentNotLast) {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithNonStringRestArgument(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Rest int `rest:"true"`
+	}) {
+	})
+	if !errors.Is(err, ErrRestArgumentNotString) {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestNewCommandWithInvalidSliceDefault(t *testing.T) {
+	parser := New("")
+
+	err := parser.NewCommand("", "", func(a *discordgo.MessageCreate, b struct {
+		Args []int `default:"1 A"`
+	}) {
+	})
+	var defaultErr *InvalidDefaultValueError
+	if !errors.As(err, &defaultErr) {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestRegisterWithNonStructArguments(t *testing.T) {
+	parser := New("")
+
+	err := Register(parser, "", "", func(ctx *Context, args string) error { return nil })
+	if errors.Unwrap(err) != ErrHandlerInvalidSecondParameterType {
+		t.Error("parser did not return correct error")
+	}
+}
+
+func TestRegisterWithValidData(t *testing.T) {
+	parser := New("")
+
+	err := Register(parser, "", "", func(ctx *Context, args struct{}) error { return nil })
+	if err != nil {
+		t.Errorf("adding command returned unexpected error")
+	}
+}
+
 func TestRunCommandWithNotMatchingPrefix(t *testing.T) {
 	parser := New("TEST")
 
@@ -324,6 +519,56 @@ func TestRunCommandWithInvalidFloat64Argument(t *testing.T) {
 	}
 }
 
+func TestRunCommandWithInvalidArgumentReportsArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Name  string
+			Count int
+		},
+	) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test a ABC"}})
+	var argErr *ArgumentError
+	if !errors.As(err, &argErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if argErr.Argument != "Count" || argErr.Position != 2 || argErr.Value != "ABC" || argErr.Type != "int" {
+		t.Errorf("error contained incorrect details %+v", argErr)
+	}
+	if argErr.Usage != ".test <Name> <Count>" {
+		t.Errorf("error contained incorrect usage %s", argErr.Usage)
+	}
+}
+
+func TestRunCommandWithMissingArgumentsReportsAllArguments(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			First  string
+			Second string
+			Third  string `default:""`
+			Fourth string
+		},
+	) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}})
+	if !errors.Is(err, ErrRequiredArgumentMissing) {
+		t.Errorf("running command did not return correct error")
+	}
+	var missingErr *MissingArgumentsError
+	if !errors.As(err, &missingErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if diff := deep.Equal(missingErr.Arguments, []string{"First", "Second", "Fourth"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
 func TestRunCommandWithDefaultArgumentValue(t *testing.T) {
 	parser := New(".")
 	parser.NewCommand("test", "", func(
@@ -343,110 +588,461 @@ func TestRunCommandWithDefaultArgumentValue(t *testing.T) {
 	}
 }
 
-func TestRunCommandWithEmptyCommandName(t *testing.T) {
+func TestRunCommandWithNamedTypeArgument(t *testing.T) {
+	type count uint16
+
 	parser := New(".")
-	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct{}) {})
+	parser.NewCommand("test", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Arg count
+		},
+	) {
+		if args.Arg != 300 {
+			t.Errorf("handler was not passed correct value for named type arg")
+		}
+	})
 
-	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "."}})
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test 300"}})
 	if err != nil {
 		t.Errorf("running command returned unexpected error")
 	}
 }
 
-func TestRunCommandWithRequiredValProvidedAsKwarg(t *testing.T) {
+func TestRunCommandWithSliceArgument(t *testing.T) {
 	parser := New(".")
-	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct {
-		Arg string
-	}) {
-		if args.Arg != "kwargval" {
-			t.Errorf("handler was not passed correct value for kwarg")
+	called := false
+	parser.NewCommand("test", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Arg  string
+			Args []int
+		},
+	) {
+		called = true
+		if args.Arg != "A" {
+			t.Errorf("handler was not passed correct value for arg")
+		}
+		if diff := deep.Equal(args.Args, []int{1, 2, 3}); diff != nil {
+			t.Error(diff)
 		}
 	})
 
-	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ". Arg=kwargval"}})
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test A 1 2 3"}})
 	if err != nil {
 		t.Errorf("running command returned unexpected error")
 	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
 }
 
-func TestRunCommandWithKwargInMiddle(t *testing.T) {
+func TestRunCommandWithInvalidSliceArgument(t *testing.T) {
 	parser := New(".")
-	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct {
-		Arg1 string
-		Arg2 string
-		Arg3 string
-	}) {
+	parser.NewCommand("test", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Args []int
+		},
+	) {
 	})
 
-	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ". A Arg2=B C"}})
-	if !errors.Is(err, ErrKwargsMustBeAtEnd) {
-		t.Errorf("running command returned incorrect error")
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test 1 ABC"}})
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("running command did not return correct error")
 	}
 }
 
-func TestRunCommandWithKwargAndDefault(t *testing.T) {
+func TestRunCommandWithMissingSliceArgument(t *testing.T) {
 	parser := New(".")
-	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct {
-		Arg1 string `default:"A"`
-		Arg2 string `default:"B"`
-	}) {
-		if args.Arg2 != "overridden" {
-			t.Errorf("handler was not provided correct value for kwarg")
-		}
+	parser.NewCommand("test", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Args []string
+		},
+	) {
 	})
 
-	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ". Arg2=overridden"}})
-	if err != nil {
-		t.Errorf("running command returned unexpected error")
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}})
+	if !errors.Is(err, ErrRequiredArgumentMissing) {
+		t.Errorf("running command did not return correct error")
 	}
 }
 
-func TestGetCommandWithUnknownCommand(t *testing.T) {
-	parser := New("")
+func TestRunCommandWithSliceArgumentDefault(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Args []string `default:"a 'b c'"`
+		},
+	) {
+		if diff := deep.Equal(args.Args, []string{"a", "b c"}); diff != nil {
+			t.Error(diff)
+		}
+	})
 
-	_, err := parser.GetCommand("")
-	if !errors.Is(err, ErrUnknownCommand) {
-		t.Errorf("function did not return expected error")
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
 	}
 }
 
-func TestGetCommandWithCommandWithRequiredArg(t *testing.T) {
-	parser := New("")
-	parser.NewCommand("", "", func(
+func TestRunCommandWithSliceArgumentAsKwargs(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(
 		message *discordgo.MessageCreate,
 		args struct {
-			Test string
-		}) {
+			Args []string
+		},
+	) {
+		if diff := deep.Equal(args.Args, []string{"a", "b"}); diff != nil {
+			t.Error(diff)
+		}
 	})
 
-	command, err := parser.GetCommand("")
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test Args=a Args=b"}})
 	if err != nil {
-		t.Errorf("got unexpected error")
-	}
-
-	if diff := deep.Equal(command, CommandDetails{
-		Name:        "",
-		Description: "",
-		Arguments: []ArgumentDetails{
-			{
-				Name:        "Test",
-				Type:        "string",
-				Description: "No description provided.",
-				Required:    true,
-				Default:     "",
-			},
-		},
-	}); diff != nil {
-		t.Error(diff)
+		t.Errorf("running command returned unexpected error")
 	}
 }
 
-func TestGetCommandWithCommandWithDefaultArg(t *testing.T) {
-	parser := New("")
-	parser.NewCommand("", "", func(
+func TestRunCommandWithRestArgument(t *testing.T) {
+	parser := New(".")
+	called := false
+	parser.NewCommand("tag", "", func(
 		message *discordgo.MessageCreate,
 		args struct {
-			Test float64 `default:"1.25"`
+			Name string
+			Text string `rest:"true"`
+		},
+	) {
+		called = true
+		if args.Name != "name" {
+			t.Errorf("handler was not passed correct value for arg")
+		}
+		if args.Text != "some  \"long\"\n  text " {
+			t.Errorf("handler was passed incorrect value for rest arg %q", args.Text)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".tag name some  \"long\"\n  text "}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestRunCommandWithRestArgumentContainingKwargs(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("tag", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Text string `rest:"true"`
+		},
+	) {
+		if args.Text != "a Text=b" {
+			t.Errorf("handler was passed incorrect value for rest arg %q", args.Text)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".tag a Text=b"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithRestArgumentContainingUnclosedDelimiters(t *testing.T) {
+	for _, input := range []string{`He said "hi`, "use ` for code", "```not closed", `"hi`, `a "b c`} {
+		t.Run(input, func(t *testing.T) {
+			parser := New(".")
+			called := false
+			parser.NewCommand("say", "", func(ctx *Context, args struct {
+				Text string `rest:"true"`
+			}) {
+				called = true
+				if args.Text != input {
+					t.Errorf("handler was passed incorrect value for rest arg %q", args.Text)
+				}
+				if diff := deep.Equal(ctx.Arguments, []string{input}); diff != nil {
+					t.Error(diff)
+				}
+			})
+
+			err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".say " + input}})
+			if err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+			if !called {
+				t.Errorf("handler was not called")
+			}
+		})
+	}
+}
+
+func TestRunCommandWithUnclosedQuoteBeforeRestArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("tag", "", func(message *discordgo.MessageCreate, args struct {
+		Name string
+		Text string `rest:"true"`
+	}) {
+		t.Errorf("handler was called")
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: `.tag "name text`}})
+	if !errors.Is(err, ErrUnclosedQuote) {
+		t.Errorf("running command did not return correct error: %v", err)
+	}
+}
+
+func TestRunCommandWithMissingRestArgument(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("tag", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Text string `rest:"true"`
+		},
+	) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".tag"}})
+	if !errors.Is(err, ErrRequiredArgumentMissing) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestRunCommandWithEmptyCommandName(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "."}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithRequiredValProvidedAsKwarg(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct {
+		Arg string
+	}) {
+		if args.Arg != "kwargval" {
+			t.Errorf("handler was not passed correct value for kwarg")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ". Arg=kwargval"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithKwargInMiddle(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct {
+		Arg1 string
+		Arg2 string
+		Arg3 string
+	}) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ". A Arg2=B C"}})
+	if !errors.Is(err, ErrKwargsMustBeAtEnd) {
+		t.Errorf("running command returned incorrect error")
+	}
+}
+
+func TestRunCommandWithKwargAndDefault(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct {
+		Arg1 string `default:"A"`
+		Arg2 string `default:"B"`
+	}) {
+		if args.Arg2 != "overridden" {
+			t.Errorf("handler was not provided correct value for kwarg")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ". Arg2=overridden"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithHandlerReturningError(t *testing.T) {
+	handlerErr := errors.New("handler failed")
+	parser := New(".")
+	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct{}) error {
+		return handlerErr
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "."}})
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("running command did not return handler error")
+	}
+}
+
+func TestRunCommandWithHandlerReturningNilError(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct{}) error {
+		return nil
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "."}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestRunCommandWithContextHandler(t *testing.T) {
+	parser := New(".")
+	message := &discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test A B"}}
+	parser.NewCommand("test", "", func(ctx *Context, args struct {
+		Arg1 string
+		Arg2 string
+	}) error {
+		if ctx.Message != message {
+			t.Errorf("handler was not passed correct message")
+		}
+		if ctx.Command != "test" {
+			t.Errorf("handler was passed incorrect command name %s", ctx.Command)
+		}
+		if diff := deep.Equal(ctx.Arguments, []string{"A", "B"}); diff != nil {
+			t.Error(diff)
+		}
+		if ctx.Session != nil {
+			t.Errorf("handler was passed unexpected session")
+		}
+		return nil
+	})
+
+	err := parser.RunCommand(message)
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestExecuteWithSession(t *testing.T) {
+	parser := New(".")
+	session := &discordgo.Session{}
+	parser.NewCommand("", "", func(ctx *Context, args struct{}) {
+		if ctx.Session != session {
+			t.Errorf("handler was not passed correct session")
+		}
+	})
+
+	err := parser.Execute(context.Background(), session, &discordgo.MessageCreate{Message: &discordgo.Message{Content: "."}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+}
+
+func TestExecuteCancelsContextAfterHandler(t *testing.T) {
+	parser := New(".")
+	var commandCtx *Context
+	parser.NewCommand("", "", func(ctx *Context, args struct{}) {
+		if ctx.Err() != nil {
+			t.Errorf("context was cancelled before handler returned")
+		}
+		commandCtx = ctx
+	})
+
+	err := parser.Execute(context.Background(), nil, &discordgo.MessageCreate{Message: &discordgo.Message{Content: "."}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+	if commandCtx.Err() != context.Canceled {
+		t.Errorf("context was not cancelled after handler returned")
+	}
+}
+
+func TestRunCommandWithRegisteredCommand(t *testing.T) {
+	type testArgs struct {
+		Arg1 string
+		Arg2 int `default:"5"`
+	}
+
+	parser := New(".")
+	called := false
+	Register(parser, "test", "", func(ctx *Context, args testArgs) error {
+		called = true
+		if diff := deep.Equal(args, testArgs{"A", 5}); diff != nil {
+			t.Error(diff)
+		}
+		return nil
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test A"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error")
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestRunCommandWithRegisteredCommandReturningError(t *testing.T) {
+	handlerErr := errors.New("handler failed")
+	parser := New(".")
+	Register(parser, "", "", func(ctx *Context, args struct{}) error {
+		return handlerErr
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "."}})
+	if !errors.Is(err, handlerErr) {
+		t.Errorf("running command did not return handler error")
+	}
+}
+
+func TestGetCommandWithUnknownCommand(t *testing.T) {
+	parser := New("")
+
+	_, err := parser.GetCommand("")
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("function did not return expected error")
+	}
+}
+
+func TestGetCommandWithCommandWithRequiredArg(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Test string
+		}) {
+	})
+
+	command, err := parser.GetCommand("")
+	if err != nil {
+		t.Errorf("got unexpected error")
+	}
+
+	if diff := deep.Equal(command, CommandDetails{
+		Name:        "",
+		Description: "",
+		Arguments: []ArgumentDetails{
+			{
+				Name:        "Test",
+				Type:        "string",
+				Description: "No description provided.",
+				Required:    true,
+				Default:     "",
+				Flag:        "test",
+			},
+		},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestGetCommandWithCommandWithDefaultArg(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("", "", func(
+		message *discordgo.MessageCreate,
+		args struct {
+			Test float64 `default:"1.25"`
 		}) {
 	})
 
@@ -465,6 +1061,7 @@ func TestGetCommandWithCommandWithDefaultArg(t *testing.T) {
 				Description: "No description provided.",
 				Required:    false,
 				Default:     "1.25",
+				Flag:        "test",
 			},
 		},
 	}); diff != nil {
@@ -496,6 +1093,38 @@ func TestGetCommandWithCommandWithArgumentWithDescription(t *testing.T) {
 				Description: "Test",
 				Required:    true,
 				Default:     "",
+				Flag:        "test",
+			},
+		},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestGetCommandWithRegisteredCommand(t *testing.T) {
+	parser := New("")
+	Register(parser, "test", "Description", func(ctx *Context, args struct {
+		Test int `default:"1" description:"Test"`
+	}) error {
+		return nil
+	})
+
+	command, err := parser.GetCommand("test")
+	if err != nil {
+		t.Errorf("got unexpected error")
+	}
+
+	if diff := deep.Equal(command, CommandDetails{
+		Name:        "test",
+		Description: "Description",
+		Arguments: []ArgumentDetails{
+			{
+				Name:        "Test",
+				Type:        "int",
+				Description: "Test",
+				Required:    false,
+				Default:     "1",
+				Flag:        "test",
 			},
 		},
 	}); diff != nil {
@@ -544,3 +1173,567 @@ func TestGetCommandsWithNoCommands(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestRunCommandWithPanickingHandler(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		var user *discordgo.User
+		_ = user.ID
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if panicErr.Command != "test" {
+		t.Errorf("error contained incorrect command name %s", panicErr.Command)
+	}
+	if len(panicErr.Stack) == 0 {
+		t.Errorf("error did not contain stack trace")
+	}
+	var runtimeErr runtime.Error
+	if !errors.As(err, &runtimeErr) {
+		t.Errorf("error did not wrap runtime error")
+	}
+}
+
+func TestRunCommandWithPanickingConverter(t *testing.T) {
+	type panicking struct{}
+
+	parser := New(".")
+	parser.RegisterConverter(reflect.TypeOf(panicking{}), func(ctx *Context, raw string) (interface{}, error) {
+		panic("converter panicked")
+	})
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Arg panicking
+	}) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test a"}})
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if panicErr.Value != "converter panicked" {
+		t.Errorf("error contained incorrect panic value %v", panicErr.Value)
+	}
+}
+
+func TestRunCommandWithPanickingHandlerAndRepanic(t *testing.T) {
+	parser := New(".")
+	parser.SetRepanic(true)
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		panic("handler panicked")
+	})
+
+	defer func() {
+		if value := recover(); value != "handler panicked" {
+			t.Errorf("running command did not re-panic with original value, got %v", value)
+		}
+	}()
+	parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: ".test"}})
+	t.Errorf("running command did not panic")
+}
+
+func TestRunCommandWithMultiplePrefixes(t *testing.T) {
+	parser := New("!", "?")
+	var prefixes []string
+	parser.NewCommand("ping", "", func(ctx *Context, args struct{}) {
+		prefixes = append(prefixes, ctx.Prefix)
+	})
+
+	for _, content := range []string{"!ping", "?ping", ".ping"} {
+		err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: content}})
+		if err != nil {
+			t.Errorf("running %q returned unexpected error: %s", content, err)
+		}
+	}
+
+	if diff := deep.Equal(prefixes, []string{"!", "?"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithOverlappingPrefixes(t *testing.T) {
+	parser := New("!", "!!")
+	called := false
+	parser.NewCommand("ping", "", func(ctx *Context, args struct{}) {
+		called = true
+		if ctx.Prefix != "!!" {
+			t.Errorf("handler was passed incorrect prefix %s", ctx.Prefix)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!!ping"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestRunCommandWithMentionPrefix(t *testing.T) {
+	session := _TestSession(t, _SendingTransport())
+	session.State.User = &discordgo.User{ID: "900"}
+
+	parser := New("!")
+	parser.SetMentionPrefix(true)
+	var prefixes []string
+	parser.NewCommand("ping", "", func(ctx *Context, args struct{ Target string }) {
+		prefixes = append(prefixes, ctx.Prefix)
+		if args.Target != "a" {
+			t.Errorf("handler was not passed correct args")
+		}
+	})
+
+	for _, content := range []string{"<@900> ping a", "<@!900>ping a", "!ping a", "<@901> ping a"} {
+		err := parser.Execute(context.Background(), session, &discordgo.MessageCreate{Message: &discordgo.Message{Content: content}})
+		if err != nil {
+			t.Errorf("running %q returned unexpected error: %s", content, err)
+		}
+	}
+
+	if diff := deep.Equal(prefixes, []string{"<@900>", "<@!900>", "!"}); diff != nil {
+		t.Error(diff)
+	}
+
+	err := parser.Execute(context.Background(), session, &discordgo.MessageCreate{Message: &discordgo.Message{Content: "<@900> ping"}})
+	var missingErr *MissingArgumentsError
+	if !errors.As(err, &missingErr) || missingErr.Usage != "<@900> ping <Target>" {
+		t.Errorf("running command did not return error with correct usage: %v", err)
+	}
+}
+
+func TestRunCommandWithMentionPrefixWithoutSession(t *testing.T) {
+	parser := New("!")
+	parser.SetMentionPrefix(true)
+	parser.NewCommand("ping", "", func(ctx *Context, args struct{}) {
+		t.Errorf("handler was called")
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "<@900> ping"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithFlags(t *testing.T) {
+	tests := []struct {
+		content string
+		reason  string
+		days    int
+		silent  bool
+	}{
+		{"!ban bob --reason spam", "spam", 0, false},
+		{"!ban bob --reason=spam --days 7", "spam", 7, false},
+		{"!ban bob -r spam -d=7", "spam", 7, false},
+		{`!ban bob --silent -r "being rude"`, "being rude", 0, true},
+		{"!ban bob --silent=false Reason=spam", "spam", 0, false},
+	}
+
+	for _, test := range tests {
+		parser := New("!")
+		called := false
+		parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+			User   string
+			Reason string `default:"" short:"r"`
+			Days   int    `default:"0" short:"d"`
+			Silent bool   `default:"false"`
+		}) {
+			called = true
+			if args.User != "bob" || args.Reason != test.reason || args.Days != test.days || args.Silent != test.silent {
+				t.Errorf("running %q passed incorrect arguments %+v", test.content, args)
+			}
+		})
+
+		if err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: test.content}}); err != nil {
+			t.Errorf("running %q returned unexpected error: %s", test.content, err)
+		}
+		if !called {
+			t.Errorf("running %q did not call handler", test.content)
+		}
+	}
+}
+
+func TestRunCommandWithBooleanFlagWithoutDefault(t *testing.T) {
+	for content, expected := range map[string]bool{"!b": false, "!b --silent": true} {
+		parser := New("!")
+		called := false
+		parser.NewCommand("b", "", func(message *discordgo.MessageCreate, args struct {
+			Silent bool
+		}) {
+			called = true
+			if args.Silent != expected {
+				t.Errorf("running %q passed incorrect arguments %+v", content, args)
+			}
+		})
+
+		if err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: content}}); err != nil {
+			t.Errorf("running %q returned unexpected error: %s", content, err)
+		}
+		if !called {
+			t.Errorf("running %q did not call handler", content)
+		}
+		if usage, _ := parser.Usage("b"); usage != "!b [Silent=false]" {
+			t.Errorf("got incorrect usage %s", usage)
+		}
+	}
+}
+
+func TestRunCommandWithFlagErrors(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected error
+	}{
+		{"!ban bob --force", ErrUnknownFlag},
+		{"!ban bob --reason", ErrFlagMissingValue},
+		{"!ban --reason spam bob", ErrKwargsMustBeAtEnd},
+	}
+
+	for _, test := range tests {
+		parser := New("!")
+		parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+			User   string
+			Reason string `default:""`
+		}) {
+		})
+
+		err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: test.content}})
+		if !errors.Is(err, test.expected) {
+			t.Errorf("running %q returned %v, expected %v", test.content, err, test.expected)
+		}
+	}
+}
+
+func TestRunCommandWithFlagUsage(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+		User string
+	}) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!ban bob --force"}})
+	var flagErr *FlagError
+	if !errors.As(err, &flagErr) {
+		t.Fatalf("running command returned %v, expected a *FlagError", err)
+	}
+	if flagErr.Flag != "--force" || flagErr.Usage != "!ban <User>" {
+		t.Errorf("running command returned incorrect error %+v", flagErr)
+	}
+}
+
+func TestRunCommandWithNegativeNumberAndShortFlags(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("add", "", func(message *discordgo.MessageCreate, args struct {
+		Value int
+		Scale int `default:"1" short:"s"`
+	}) {
+		if args.Value != -5 || args.Scale != 2 {
+			t.Errorf("handler was passed incorrect arguments %+v", args)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!add -5 -s 2"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithFlagTerminator(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("say", "", func(message *discordgo.MessageCreate, args struct {
+		Text string `rest:"true"`
+	}) {
+		if args.Text != "--text hello" {
+			t.Errorf("handler was passed incorrect arguments %+v", args)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!say -- --text hello"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithRestArgumentStartingWithDashes(t *testing.T) {
+	for _, input := range []string{"--help me", "-->", "-v is verbose", "--text hello"} {
+		t.Run(input, func(t *testing.T) {
+			parser := New("!")
+			parser.NewCommand("say", "", func(message *discordgo.MessageCreate, args struct {
+				Text string `rest:"true"`
+			}) {
+				if args.Text != input {
+					t.Errorf("handler was passed incorrect arguments %+v", args)
+				}
+			})
+
+			err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!say " + input}})
+			if err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestRunCommandWithFlagNameTag(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("mute", "", func(message *discordgo.MessageCreate, args struct {
+		UserID   string
+		Duration string `name:"for" default:"1h"`
+	}) {
+		if args.UserID != "1" || args.Duration != "2h" {
+			t.Errorf("handler was passed incorrect arguments %+v", args)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!mute --user-id 1 --for 2h"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestNewCommandWithInvalidFlags(t *testing.T) {
+	parser := New("!")
+
+	err := parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Reason string `short:"re"`
+	}) {
+	})
+	if !errors.Is(err, ErrInvalidFlagName) {
+		t.Errorf("registering command with invalid short flag returned %v", err)
+	}
+
+	err = parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		Reason string `short:"r"`
+		Role   string `short:"r"`
+	}) {
+	})
+	if !errors.Is(err, ErrFlagNameConflict) {
+		t.Errorf("registering command with conflicting short flags returned %v", err)
+	}
+
+	err = parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct {
+		DryRun bool
+		Dry    bool `name:"dry-run"`
+	}) {
+	})
+	if !errors.Is(err, ErrFlagNameConflict) {
+		t.Errorf("registering command with conflicting flags returned %v", err)
+	}
+}
+
+func TestKebabCase(t *testing.T) {
+	tests := map[string]string{
+		"Reason":     "reason",
+		"UserID":     "user-id",
+		"HTTPServer": "http-server",
+		"DryRun":     "dry-run",
+		"Snake_Case": "snake-case",
+		"Page2Size":  "page2-size",
+	}
+
+	for name, expected := range tests {
+		if flag := _KebabCase(name); flag != expected {
+			t.Errorf("converting %q returned %q, expected %q", name, flag, expected)
+		}
+	}
+}
+
+func TestRunCommandWithArgumentNameTag(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+		Target string `name:"user"`
+		Reason string `default:"" aliases:"why, r"`
+	}) {
+		if args.Target != "bob" || args.Reason != "spam" {
+			t.Errorf("handler was passed incorrect arguments %+v", args)
+		}
+	})
+
+	for _, content := range []string{"!ban user=bob why=spam", "!ban --user bob --why spam", "!ban bob r=spam"} {
+		if err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: content}}); err != nil {
+			t.Errorf("running %q returned unexpected error: %s", content, err)
+		}
+	}
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!ban"}})
+	var missingErr *MissingArgumentsError
+	if !errors.As(err, &missingErr) || !reflect.DeepEqual(missingErr.Arguments, []string{"user"}) {
+		t.Errorf("running command without arguments returned %v, expected missing user", err)
+	}
+
+	command, err := parser.GetCommand("ban")
+	if err != nil {
+		t.Fatalf("getting command returned unexpected error: %s", err)
+	}
+	if command.Arguments[0].Name != "user" || !reflect.DeepEqual(command.Arguments[1].Aliases, []string{"why", "r"}) {
+		t.Errorf("command details contain incorrect names %+v", command.Arguments)
+	}
+}
+
+func TestRunCommandWithCaseInsensitiveKeywords(t *testing.T) {
+	parser := New("!")
+	value := ""
+	parser.NewCommand("", "", func(message *discordgo.MessageCreate, args struct {
+		Reason string `aliases:"Why"`
+	}) {
+		value = args.Reason
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "! reason=spam"}})
+	if err != nil {
+		t.Fatalf("running command returned unexpected error: %s", err)
+	}
+	if value != "reason=spam" {
+		t.Errorf("keyword was matched case-insensitively without being enabled, got %q", value)
+	}
+
+	parser.SetCaseInsensitiveKeywords(true)
+	for _, content := range []string{"! reason=spam", "! REASON=spam", "! why=spam"} {
+		value = ""
+		if err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: content}}); err != nil {
+			t.Errorf("running %q returned unexpected error: %s", content, err)
+		}
+		if value != "spam" {
+			t.Errorf("running %q passed incorrect value %q", content, value)
+		}
+	}
+}
+
+func TestNewCommandWithInvalidArgumentNames(t *testing.T) {
+	tests := []struct {
+		args     interface{}
+		expected error
+	}{
+		{struct {
+			Reason string `name:""`
+		}{}, ErrInvalidArgumentName},
+		{struct {
+			Reason string `name:"the reason"`
+		}{}, ErrInvalidArgumentName},
+		{struct {
+			Reason string `aliases:"why,"`
+		}{}, ErrInvalidArgumentName},
+		{struct {
+			Reason string
+			Why    string `aliases:"reason"`
+		}{}, ErrArgumentNameConflict},
+		{struct {
+			Reason string `aliases:"why,Why"`
+		}{}, ErrArgumentNameConflict},
+	}
+
+	for _, test := range tests {
+		argsType := reflect.TypeOf(test.args)
+		_, err := _NewBindingPlan(argsType, map[reflect.Type]ConverterFunc{})
+		if !errors.Is(err, test.expected) {
+			t.Errorf("building plan for %s returned %v, expected %v", argsType, err, test.expected)
+		}
+	}
+}
+
+func TestRunCommandWithFlexibleOrdering(t *testing.T) {
+	tests := []struct {
+		content string
+		user    string
+		reason  string
+		days    int
+	}{
+		{"!ban --reason spam bob", "bob", "spam", 0},
+		{"!ban Reason=spam bob 7", "bob", "spam", 7},
+		{"!ban User=bob spam", "bob", "spam", 0},
+		{"!ban -d 7 bob spam", "bob", "spam", 7},
+		{"!ban bob Days=7 spam", "bob", "spam", 7},
+	}
+
+	for _, test := range tests {
+		parser := New("!")
+		parser.SetFlexibleOrdering(true)
+		called := false
+		parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+			User   string
+			Reason string `default:""`
+			Days   int    `default:"0" short:"d"`
+		}) {
+			called = true
+			if args.User != test.user || args.Reason != test.reason || args.Days != test.days {
+				t.Errorf("running %q passed incorrect arguments %+v", test.content, args)
+			}
+		})
+
+		if err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: test.content}}); err != nil {
+			t.Errorf("running %q returned unexpected error: %s", test.content, err)
+		}
+		if !called {
+			t.Errorf("running %q did not call handler", test.content)
+		}
+	}
+}
+
+func TestRunCommandWithFlexibleOrderingAndRestArgument(t *testing.T) {
+	parser := New("!")
+	parser.SetFlexibleOrdering(true)
+	parser.NewCommand("say", "", func(message *discordgo.MessageCreate, args struct {
+		Channel string `default:"general"`
+		Loud    bool   `default:"false"`
+		Text    string `rest:"true"`
+	}) {
+		if args.Channel != "general" || !args.Loud || args.Text != "#random hello --loud" {
+			t.Errorf("handler was passed incorrect arguments %+v", args)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!say --loud Channel=general #random hello --loud"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+}
+
+func TestRunCommandWithConflictingArguments(t *testing.T) {
+	tests := []struct {
+		content  string
+		flexible bool
+		expected error
+	}{
+		{"!ban bob Reason=spam Reason=abuse", false, ErrDuplicateArgument},
+		{"!ban bob --reason spam -r abuse", false, ErrDuplicateArgument},
+		{"!ban bob User=alice", false, ErrArgumentPositionalAndNamed},
+		{"!ban Reason=spam Reason=abuse bob", true, ErrDuplicateArgument},
+		{"!ban bob spam --reason abuse", true, ErrArgumentPositionalAndNamed},
+		{"!ban bob Reason=spam abuse", false, ErrKwargsMustBeAtEnd},
+	}
+
+	for _, test := range tests {
+		parser := New("!")
+		parser.SetFlexibleOrdering(test.flexible)
+		parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+			User   string
+			Reason string `default:"" short:"r"`
+		}) {
+		})
+
+		err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: test.content}})
+		if !errors.Is(err, test.expected) {
+			t.Errorf("running %q returned %v, expected %v", test.content, err, test.expected)
+		}
+	}
+}
+
+func TestRunCommandWithConflictingArgumentsUsage(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+		User string
+	}) {
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!ban bob User=alice"}})
+	var conflictErr *ArgumentConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("running command returned %v, expected an *ArgumentConflictError", err)
+	}
+	if conflictErr.Argument != "User" || conflictErr.Usage != "!ban <User>" {
+		t.Errorf("running command returned incorrect error %+v", conflictErr)
+	}
+}
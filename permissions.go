@@ -0,0 +1,131 @@
+package parsley
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// _PermissionNames contains the names Discord displays for each permission, in the order of their bits.
+var _PermissionNames = []struct {
+	permission int64
+	name       string
+}{
+	{discordgo.PermissionCreateInstantInvite, "Create Invite"},
+	{discordgo.PermissionKickMembers, "Kick Members"},
+	{discordgo.PermissionBanMembers, "Ban Members"},
+	{discordgo.PermissionAdministrator, "Administrator"},
+	{discordgo.PermissionManageChannels, "Manage Channels"},
+	{discordgo.PermissionManageServer, "Manage Server"},
+	{discordgo.PermissionAddReactions, "Add Reactions"},
+	{discordgo.PermissionViewAuditLogs, "View Audit Log"},
+	{discordgo.PermissionVoicePrioritySpeaker, "Priority Speaker"},
+	{discordgo.PermissionVoiceStreamVideo, "Video"},
+	{discordgo.PermissionViewChannel, "View Channels"},
+	{discordgo.PermissionSendMessages, "Send Messages"},
+	{discordgo.PermissionSendTTSMessages, "Send Text-to-Speech Messages"},
+	{discordgo.PermissionManageMessages, "Manage Messages"},
+	{discordgo.PermissionEmbedLinks, "Embed Links"},
+	{discordgo.PermissionAttachFiles, "Attach Files"},
+	{discordgo.PermissionReadMessageHistory, "Read Message History"},
+	{discordgo.PermissionMentionEveryone, "Mention Everyone"},
+	{discordgo.PermissionUseExternalEmojis, "Use External Emojis"},
+	{discordgo.PermissionViewGuildInsights, "View Server Insights"},
+	{discordgo.PermissionVoiceConnect, "Connect"},
+	{discordgo.PermissionVoiceSpeak, "Speak"},
+	{discordgo.PermissionVoiceMuteMembers, "Mute Members"},
+	{discordgo.PermissionVoiceDeafenMembers, "Deafen Members"},
+	{discordgo.PermissionVoiceMoveMembers, "Move Members"},
+	{discordgo.PermissionVoiceUseVAD, "Use Voice Activity"},
+	{discordgo.PermissionChangeNickname, "Change Nickname"},
+	{discordgo.PermissionManageNicknames, "Manage Nicknames"},
+	{discordgo.PermissionManageRoles, "Manage Roles"},
+	{discordgo.PermissionManageWebhooks, "Manage Webhooks"},
+	{discordgo.PermissionManageEmojis, "Manage Emojis and Stickers"},
+	{discordgo.PermissionUseSlashCommands, "Use Application Commands"},
+	{discordgo.PermissionVoiceRequestToSpeak, "Request to Speak"},
+	{discordgo.PermissionManageEvents, "Manage Events"},
+	{discordgo.PermissionManageThreads, "Manage Threads"},
+	{discordgo.PermissionCreatePublicThreads, "Create Public Threads"},
+	{discordgo.PermissionCreatePrivateThreads, "Create Private Threads"},
+	{discordgo.PermissionUseExternalStickers, "Use External Stickers"},
+	{discordgo.PermissionSendMessagesInThreads, "Send Messages in Threads"},
+	{discordgo.PermissionUseActivities, "Use Activities"},
+	{discordgo.PermissionModerateMembers, "Timeout Members"},
+}
+
+// PermissionNames returns the names of each of the permissions contained in the provided permission bitset, such as "Ban Members".
+// Permissions without a known name are described by their bit value.
+func PermissionNames(permissions int64) []string {
+	names := make([]string, 0)
+	for _, permission := range _PermissionNames {
+		if permissions&permission.permission != 0 {
+			names = append(names, permission.name)
+			permissions &^= permission.permission
+		}
+	}
+	for bit := 0; bit < 64; bit++ {
+		if permission := int64(1) << bit; permissions&permission != 0 {
+			names = append(names, fmt.Sprintf("0x%x", uint64(permission)))
+		}
+	}
+	return names
+}
+
+// _CheckPermissions ensures that both the author of the message and the bot have the permissions the command requires
+// in the channel the message was sent in.
+func _CheckPermissions(ctx *Context, command *Command) error {
+	if command.userPermissions == 0 && command.botPermissions == 0 {
+		return nil
+	}
+	if ctx.Session == nil {
+		return ErrSessionUnavailable
+	}
+
+	if command.userPermissions != 0 {
+		if err := _CheckUserPermissions(ctx, ctx.Message.Author, command.userPermissions, false); err != nil {
+			return err
+		}
+	}
+	if command.botPermissions != 0 {
+		var botUser *discordgo.User
+		if ctx.Session.State != nil {
+			botUser = ctx.Session.State.User
+		}
+		if err := _CheckUserPermissions(ctx, botUser, command.botPermissions, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// _CheckUserPermissions ensures that the provided user has the required permissions in the channel the message was sent in.
+// Permissions are never granted outside of guilds, and threads use the permissions of their parent channel.
+func _CheckUserPermissions(ctx *Context, user *discordgo.User, required int64, bot bool) error {
+	if ctx.Message.GuildID == "" {
+		return &MissingPermissionsError{Bot: bot, Permissions: required}
+	}
+	if user == nil {
+		return fmt.Errorf("unable to determine permissions: %w", ErrSessionUnavailable)
+	}
+
+	channel, err := _FetchChannel(ctx.Session, ctx.Message.ChannelID)
+	if err != nil {
+		return fmt.Errorf("unable to determine permissions: %w", err)
+	}
+	channelID := channel.ID
+	if channel.IsThread() {
+		channelID = channel.ParentID
+	}
+
+	permissions, err := ctx.Session.UserChannelPermissions(user.ID, channelID)
+	if err != nil {
+		return fmt.Errorf("unable to determine permissions for %s: %w", user.ID, err)
+	}
+
+	if missing := required &^ permissions; missing != 0 {
+		return &MissingPermissionsError{Bot: bot, Permissions: missing}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package parsley
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestRunCommandWithUserPermissions(t *testing.T) {
+	parser := New(".")
+	called := false
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		called = true
+	}, WithUserPermissions(discordgo.PermissionBanMembers|discordgo.PermissionManageMessages))
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test", "200", "300", "100"))
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestRunCommandWithMissingUserPermissions(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		t.Errorf("handler was called")
+	}, WithUserPermissions(discordgo.PermissionBanMembers|discordgo.PermissionManageMessages))
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test", "200", "301", "100"))
+	var permissionsErr *MissingPermissionsError
+	if !errors.As(err, &permissionsErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if diff := deep.Equal(permissionsErr, &MissingPermissionsError{Permissions: discordgo.PermissionManageMessages}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithMissingUserPermissionsInThread(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		t.Errorf("handler was called")
+	}, WithUserPermissions(discordgo.PermissionManageMessages))
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test", "200", "302", "100"))
+	var permissionsErr *MissingPermissionsError
+	if !errors.As(err, &permissionsErr) {
+		t.Fatalf("running command did not return correct error, got %v", err)
+	}
+	if diff := deep.Equal(permissionsErr, &MissingPermissionsError{Permissions: discordgo.PermissionManageMessages}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithMissingBotPermissions(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		t.Errorf("handler was called")
+	}, WithBotPermissions(discordgo.PermissionSendMessages|discordgo.PermissionBanMembers))
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test", "200", "300", "100"))
+	var permissionsErr *MissingPermissionsError
+	if !errors.As(err, &permissionsErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+	if diff := deep.Equal(permissionsErr, &MissingPermissionsError{Bot: true, Permissions: discordgo.PermissionBanMembers}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithPermissionsInDirectMessage(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		t.Errorf("handler was called")
+	}, WithUserPermissions(discordgo.PermissionBanMembers))
+
+	err := parser.Execute(context.Background(), _TestSession(t, nil), _TestMessage(".test", "200", "500", ""))
+	var permissionsErr *MissingPermissionsError
+	if !errors.As(err, &permissionsErr) {
+		t.Fatalf("running command did not return correct error")
+	}
+}
+
+func TestRunCommandWithPermissionsWithoutSession(t *testing.T) {
+	parser := New(".")
+	parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+		t.Errorf("handler was called")
+	}, WithUserPermissions(discordgo.PermissionBanMembers))
+
+	err := parser.RunCommand(_TestMessage(".test", "200", "300", "100"))
+	if !errors.Is(err, ErrSessionUnavailable) {
+		t.Errorf("running command did not return correct error")
+	}
+}
+
+func TestGetCommandWithPermissions(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct{}) {},
+		WithUserPermissions(discordgo.PermissionBanMembers),
+		WithBotPermissions(discordgo.PermissionBanMembers|discordgo.PermissionEmbedLinks),
+	)
+
+	command, _ := parser.GetCommand("ban")
+	if command.UserPermissions != discordgo.PermissionBanMembers || command.BotPermissions != discordgo.PermissionBanMembers|discordgo.PermissionEmbedLinks {
+		t.Errorf("command details contained incorrect permissions")
+	}
+}
+
+func TestPermissionNames(t *testing.T) {
+	names := PermissionNames(discordgo.PermissionManageMessages | discordgo.PermissionBanMembers | 1<<60)
+	if diff := deep.Equal(names, []string{"Ban Members", "Manage Messages", "0x1000000000000000"}); diff != nil {
+		t.Error(diff)
+	}
+}
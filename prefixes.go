@@ -0,0 +1,212 @@
+package parsley
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// PrefixResolver determines the prefixes a message can use to run commands, such as to allow each guild to choose its own prefix.
+type PrefixResolver interface {
+	Prefixes(ctx context.Context, message *discordgo.MessageCreate) []string
+}
+
+// PrefixResolverFunc adapts a function into a PrefixResolver.
+type PrefixResolverFunc func(ctx context.Context, message *discordgo.MessageCreate) []string
+
+// Prefixes calls the underlying function.
+func (resolver PrefixResolverFunc) Prefixes(ctx context.Context, message *discordgo.MessageCreate) []string {
+	return resolver(ctx, message)
+}
+
+// PrefixStore is a PrefixResolver whose prefixes can be changed for each guild.
+type PrefixStore interface {
+	PrefixResolver
+	// SetPrefix sets the prefix used within the guild with the provided ID. An empty prefix restores the default prefixes.
+	SetPrefix(ctx context.Context, guildID, prefix string) error
+}
+
+// SetPrefixResolver sets the resolver used to determine the prefixes of each message, replacing the prefixes provided to New.
+// Passing nil restores the prefixes provided to New.
+func (parser *Parser) SetPrefixResolver(resolver PrefixResolver) {
+	parser.prefixResolver = resolver
+}
+
+// MemoryPrefixStore is a PrefixStore that keeps each guild's prefix in memory. It is safe for concurrent use.
+type MemoryPrefixStore struct {
+	mutex    sync.RWMutex
+	defaults []string
+	prefixes map[string]string
+}
+
+// NewMemoryPrefixStore creates a new MemoryPrefixStore, using the provided prefixes for direct messages and guilds without their own prefix.
+func NewMemoryPrefixStore(defaults ...string) *MemoryPrefixStore {
+	return &MemoryPrefixStore{defaults: append([]string(nil), defaults...), prefixes: make(map[string]string)}
+}
+
+// Prefixes returns the prefix of the guild the message was sent in, or the default prefixes if it does not have its own.
+func (store *MemoryPrefixStore) Prefixes(ctx context.Context, message *discordgo.MessageCreate) []string {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	if prefix, found := store.prefixes[message.GuildID]; found && message.GuildID != "" {
+		return []string{prefix}
+	}
+	return store.defaults
+}
+
+// SetPrefix sets the prefix used within the guild with the provided ID. An empty prefix restores the default prefixes.
+func (store *MemoryPrefixStore) SetPrefix(ctx context.Context, guildID, prefix string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.setPrefix(guildID, prefix)
+	return nil
+}
+
+func (store *MemoryPrefixStore) setPrefix(guildID, prefix string) {
+	if prefix == "" {
+		delete(store.prefixes, guildID)
+	} else {
+		store.prefixes[guildID] = prefix
+	}
+}
+
+// JSONFilePrefixStore is a PrefixStore that persists each guild's prefix to a JSON file, mapping guild IDs to prefixes.
+// It is safe for concurrent use within a single process.
+type JSONFilePrefixStore struct {
+	MemoryPrefixStore
+	path string
+}
+
+// NewJSONFilePrefixStore creates a new JSONFilePrefixStore backed by the file at the provided path, loading any prefixes it already contains.
+// The file is created when a prefix is first set if it does not already exist.
+func NewJSONFilePrefixStore(path string, defaults ...string) (*JSONFilePrefixStore, error) {
+	store := &JSONFilePrefixStore{path: path}
+	store.defaults = append([]string(nil), defaults...)
+	store.prefixes = make(map[string]string)
+
+	contents, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read prefixes: %w", err)
+	}
+	if err := json.Unmarshal(contents, &store.prefixes); err != nil {
+		return nil, fmt.Errorf("unable to parse prefixes from %s: %w", path, err)
+	}
+	if store.prefixes == nil {
+		store.prefixes = make(map[string]string)
+	}
+
+	return store, nil
+}
+
+// SetPrefix sets the prefix used within the guild with the provided ID and saves it to the store's file.
+// An empty prefix restores the default prefixes.
+func (store *JSONFilePrefixStore) SetPrefix(ctx context.Context, guildID, prefix string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	previous, hadPrevious := store.prefixes[guildID]
+	store.setPrefix(guildID, prefix)
+
+	if err := store.save(); err != nil {
+		if hadPrevious {
+			store.prefixes[guildID] = previous
+		} else {
+			delete(store.prefixes, guildID)
+		}
+		return err
+	}
+	return nil
+}
+
+// save writes the store's prefixes to a temporary file before moving it into place, so the file is never left partially written.
+func (store *JSONFilePrefixStore) save() error {
+	contents, err := json.MarshalIndent(store.prefixes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode prefixes: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(store.path), filepath.Base(store.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to save prefixes: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(contents); err != nil {
+		file.Close()
+		return fmt.Errorf("unable to save prefixes: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("unable to save prefixes: %w", err)
+	}
+	if err := os.Rename(file.Name(), store.path); err != nil {
+		return fmt.Errorf("unable to save prefixes: %w", err)
+	}
+	return nil
+}
+
+// _PrefixLengthLimit is the maximum length of a prefix that can be set using the prefix command.
+const _PrefixLengthLimit = 32
+
+// _PrefixArgs represents the arguments accepted by the built-in prefix command.
+type _PrefixArgs struct {
+	Prefix string `default:"" description:"New prefix to use in this server. Use \"reset\" to restore the default prefix."`
+}
+
+// EnablePrefixCommand registers a built-in command with the provided name that shows or changes the prefix used within a guild,
+// and uses the provided store as the parser's prefix resolver.
+//
+// When run without arguments, the command shows the current prefix. The command can only be run within guilds,
+// by members with the Manage Server permission.
+func (parser *Parser) EnablePrefixCommand(name string, store PrefixStore, options ...CommandOption) error {
+	options = append([]CommandOption{WithGuildOnly(), WithUserPermissions(discordgo.PermissionManageServer)}, options...)
+
+	err := Register(parser, name, "Shows or changes the prefix used in this server.", func(ctx *Context, args _PrefixArgs) error {
+		if ctx.Session == nil {
+			return ErrSessionUnavailable
+		}
+
+		var reply string
+		switch prefix := args.Prefix; {
+		case prefix == "":
+			reply = fmt.Sprintf("The current prefix is `%s`.", strings.Join(store.Prefixes(ctx, ctx.Message), "`, `"))
+		case prefix == "reset":
+			if err := store.SetPrefix(ctx, ctx.Message.GuildID, ""); err != nil {
+				return fmt.Errorf("error resetting prefix: %w", err)
+			}
+			reply = fmt.Sprintf("The prefix has been reset to `%s`.", strings.Join(store.Prefixes(ctx, ctx.Message), "`, `"))
+		default:
+			if utf8.RuneCountInString(prefix) > _PrefixLengthLimit || strings.IndexFunc(prefix, unicode.IsSpace) != -1 {
+				return fmt.Errorf("%w: prefixes must be at most %d characters and cannot contain spaces", ErrInvalidPrefix, _PrefixLengthLimit)
+			}
+			if err := store.SetPrefix(ctx, ctx.Message.GuildID, prefix); err != nil {
+				return fmt.Errorf("error setting prefix: %w", err)
+			}
+			reply = fmt.Sprintf("The prefix has been changed to `%s`.", prefix)
+		}
+
+		if _, err := ctx.Session.ChannelMessageSend(ctx.Message.ChannelID, reply); err != nil {
+			return fmt.Errorf("error sending reply: %w", err)
+		}
+		return nil
+	}, options...)
+	if err != nil {
+		return err
+	}
+
+	parser.SetPrefixResolver(store)
+	return nil
+}
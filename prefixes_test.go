@@ -0,0 +1,137 @@
+package parsley
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestRunCommandWithPrefixResolver(t *testing.T) {
+	parser := New("!")
+	parser.SetPrefixResolver(PrefixResolverFunc(func(ctx context.Context, message *discordgo.MessageCreate) []string {
+		if message.GuildID == "100" {
+			return []string{"?"}
+		}
+		return []string{"!"}
+	}))
+	called := 0
+	parser.NewCommand("ping", "", func(ctx *Context, args struct{}) {
+		called++
+	})
+
+	for _, message := range []*discordgo.Message{
+		{Content: "?ping", GuildID: "100"},
+		{Content: "!ping", GuildID: "100"},
+		{Content: "!ping", GuildID: "101"},
+	} {
+		if err := parser.RunCommand(&discordgo.MessageCreate{Message: message}); err != nil {
+			t.Errorf("running %q returned unexpected error: %s", message.Content, err)
+		}
+	}
+	if called != 2 {
+		t.Errorf("handler was called %d times, expected 2", called)
+	}
+}
+
+func TestMemoryPrefixStore(t *testing.T) {
+	store := NewMemoryPrefixStore("!", "?")
+	store.SetPrefix(context.Background(), "100", "$")
+
+	guildMessage := &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: "100"}}
+	if diff := deep.Equal(store.Prefixes(context.Background(), guildMessage), []string{"$"}); diff != nil {
+		t.Error(diff)
+	}
+	otherMessage := &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: "101"}}
+	if diff := deep.Equal(store.Prefixes(context.Background(), otherMessage), []string{"!", "?"}); diff != nil {
+		t.Error(diff)
+	}
+
+	store.SetPrefix(context.Background(), "100", "")
+	if diff := deep.Equal(store.Prefixes(context.Background(), guildMessage), []string{"!", "?"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestJSONFilePrefixStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefixes.json")
+
+	store, err := NewJSONFilePrefixStore(path, "!")
+	if err != nil {
+		t.Fatalf("creating store returned unexpected error: %s", err)
+	}
+	if err := store.SetPrefix(context.Background(), "100", "$"); err != nil {
+		t.Fatalf("setting prefix returned unexpected error: %s", err)
+	}
+
+	reloaded, err := NewJSONFilePrefixStore(path, "!")
+	if err != nil {
+		t.Fatalf("reloading store returned unexpected error: %s", err)
+	}
+	message := &discordgo.MessageCreate{Message: &discordgo.Message{GuildID: "100"}}
+	if diff := deep.Equal(reloaded.Prefixes(context.Background(), message), []string{"$"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestJSONFilePrefixStoreWithInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prefixes.json")
+	os.WriteFile(path, []byte("not json"), 0o600)
+
+	if _, err := NewJSONFilePrefixStore(path, "!"); err == nil {
+		t.Errorf("creating store did not return error")
+	}
+}
+
+func TestPrefixCommand(t *testing.T) {
+	transport := _SendingTransport()
+	session := _TestSession(t, transport)
+	guild, _ := session.State.Guild("100")
+	guild.OwnerID = "200"
+
+	parser := New()
+	store := NewMemoryPrefixStore("!")
+	if err := parser.EnablePrefixCommand("prefix", store); err != nil {
+		t.Fatalf("enabling prefix command returned unexpected error: %s", err)
+	}
+
+	run := func(content string) error {
+		return parser.Execute(context.Background(), session, _TestMessage(content, "200", "300", "100"))
+	}
+
+	for _, content := range []string{"!prefix $", "$prefix", "$prefix reset", "!prefix"} {
+		if err := run(content); err != nil {
+			t.Errorf("running %q returned unexpected error: %s", content, err)
+		}
+	}
+	if err := run("!prefix " + `"a b"`); !errors.Is(err, ErrInvalidPrefix) {
+		t.Errorf("running command did not return correct error")
+	}
+
+	replies := make([]string, len(transport.messages))
+	for index, message := range transport.messages {
+		replies[index] = message.Content
+	}
+	if diff := deep.Equal(replies, []string{
+		"The prefix has been changed to `$`.",
+		"The current prefix is `$`.",
+		"The prefix has been reset to `!`.",
+		"The current prefix is `!`.",
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestGetCommandWithPrefixCommand(t *testing.T) {
+	parser := New("!")
+	parser.EnablePrefixCommand("prefix", NewMemoryPrefixStore("!"))
+
+	command, _ := parser.GetCommand("prefix")
+	if !command.GuildOnly || command.UserPermissions != discordgo.PermissionManageServer {
+		t.Errorf("prefix command was not restricted")
+	}
+}
@@ -0,0 +1,130 @@
+package parsley
+
+import (
+	"fmt"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// SetOwners sets the IDs of the users that own the bot, who are the only users able to run commands registered using WithOwnerOnly.
+func (parser *Parser) SetOwners(ids ...string) {
+	parser.owners = append([]string(nil), ids...)
+}
+
+// checkRestrictions ensures that the message was sent somewhere, and by someone, the command is allowed to be run by.
+func (parser *Parser) checkRestrictions(ctx *Context, command *Command) error {
+	message := ctx.Message
+
+	if command.guildOnly && message.GuildID == "" {
+		return ErrGuildOnly
+	}
+	if command.dmOnly && message.GuildID != "" {
+		return ErrDMOnly
+	}
+	if command.ownerOnly && (message.Author == nil || !_Contains(parser.owners, message.Author.ID)) {
+		return ErrOwnerOnly
+	}
+	if len(command.allowedChannels) != 0 && !_Contains(command.allowedChannels, message.ChannelID) {
+		return ErrChannelNotAllowed
+	}
+
+	if len(command.allowedRoles) != 0 {
+		roles, err := _MessageAuthorRoles(ctx)
+		if err != nil {
+			return err
+		}
+		allowed := false
+		for _, role := range roles {
+			if _Contains(command.allowedRoles, role) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ErrRoleNotAllowed
+		}
+	}
+
+	if command.nsfwOnly {
+		nsfw, err := _IsNSFWChannel(ctx)
+		if err != nil {
+			return err
+		}
+		if !nsfw {
+			return ErrNSFWOnly
+		}
+	}
+
+	return nil
+}
+
+// _MessageAuthorRoles returns the IDs of the roles held by the author of the message.
+// Messages sent outside of guilds have no roles.
+func _MessageAuthorRoles(ctx *Context) ([]string, error) {
+	message := ctx.Message
+	if message.GuildID == "" {
+		return nil, nil
+	}
+	if message.Member != nil {
+		return message.Member.Roles, nil
+	}
+	if ctx.Session == nil {
+		return nil, ErrSessionUnavailable
+	}
+	if message.Author == nil {
+		return nil, nil
+	}
+
+	if member, err := ctx.Session.State.Member(message.GuildID, message.Author.ID); err == nil {
+		return member.Roles, nil
+	}
+	member, err := ctx.Session.GuildMember(message.GuildID, message.Author.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine roles for %s: %w", message.Author.ID, err)
+	}
+	return member.Roles, nil
+}
+
+// _IsNSFWChannel returns whether the message was sent in an age-restricted channel.
+// Threads are age-restricted when their parent channel is.
+func _IsNSFWChannel(ctx *Context) (bool, error) {
+	if ctx.Message.GuildID == "" {
+		return false, nil
+	}
+	if ctx.Session == nil {
+		return false, ErrSessionUnavailable
+	}
+
+	channel, err := _FetchChannel(ctx.Session, ctx.Message.ChannelID)
+	if err != nil {
+		return false, err
+	}
+	if channel.IsThread() {
+		channel, err = _FetchChannel(ctx.Session, channel.ParentID)
+		if err != nil {
+			return false, err
+		}
+	}
+	return channel.NSFW, nil
+}
+
+// _FetchChannel retrieves the channel with the provided ID from the session's state, falling back to the API.
+func _FetchChannel(session *discordgo.Session, id string) (*discordgo.Channel, error) {
+	if channel, err := session.State.Channel(id); err == nil {
+		return channel, nil
+	}
+	channel, err := session.Channel(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve channel %s: %w", id, err)
+	}
+	return channel, nil
+}
+
+func _Contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package parsley
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestRunCommandWithRestrictions(t *testing.T) {
+	withMember := _TestMessage(".test", "201", "300", "100")
+	withMember.Member = &discordgo.Member{Roles: []string{"401"}}
+
+	tests := []struct {
+		name     string
+		option   CommandOption
+		message  *discordgo.MessageCreate
+		expected error
+	}{
+		{"guild only in guild", WithGuildOnly(), _TestMessage(".test", "200", "300", "100"), nil},
+		{"guild only in dm", WithGuildOnly(), _TestMessage(".test", "200", "500", ""), ErrGuildOnly},
+		{"dm only in dm", WithDMOnly(), _TestMessage(".test", "200", "500", ""), nil},
+		{"dm only in guild", WithDMOnly(), _TestMessage(".test", "200", "300", "100"), ErrDMOnly},
+		{"owner only by owner", WithOwnerOnly(), _TestMessage(".test", "200", "300", "100"), nil},
+		{"owner only by other user", WithOwnerOnly(), _TestMessage(".test", "201", "300", "100"), ErrOwnerOnly},
+		{"nsfw only in nsfw channel", WithNSFWOnly(), _TestMessage(".test", "200", "301", "100"), nil},
+		{"nsfw only in nsfw thread", WithNSFWOnly(), _TestMessage(".test", "200", "302", "100"), nil},
+		{"nsfw only in other channel", WithNSFWOnly(), _TestMessage(".test", "200", "300", "100"), ErrNSFWOnly},
+		{"nsfw only in dm", WithNSFWOnly(), _TestMessage(".test", "200", "500", ""), ErrNSFWOnly},
+		{"allowed channel", WithAllowedChannels("300", "301"), _TestMessage(".test", "200", "301", "100"), nil},
+		{"disallowed channel", WithAllowedChannels("300", "301"), _TestMessage(".test", "200", "302", "100"), ErrChannelNotAllowed},
+		{"allowed role from state", WithAllowedRoles("400"), _TestMessage(".test", "200", "300", "100"), nil},
+		{"allowed role from message", WithAllowedRoles("401"), withMember, nil},
+		{"disallowed role", WithAllowedRoles("400"), withMember, ErrRoleNotAllowed},
+		{"allowed role in dm", WithAllowedRoles("400"), _TestMessage(".test", "200", "500", ""), ErrRoleNotAllowed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := New(".")
+			parser.SetOwners("200")
+			called := false
+			parser.NewCommand("test", "", func(message *discordgo.MessageCreate, args struct{}) {
+				called = true
+			}, test.option)
+
+			err := parser.Execute(context.Background(), _TestSession(t, nil), test.message)
+			if test.expected == nil && err != nil {
+				t.Errorf("running command returned unexpected error: %s", err)
+			}
+			if test.expected != nil && !errors.Is(err, test.expected) {
+				t.Errorf("running command did not return correct error, got %v", err)
+			}
+			if called != (test.expected == nil) {
+				t.Errorf("handler was called: %t, expected %t", called, test.expected == nil)
+			}
+		})
+	}
+}
+
+func TestGetCommandWithRestrictions(t *testing.T) {
+	parser := New("")
+	parser.NewCommand("purge", "", func(message *discordgo.MessageCreate, args struct{}) {},
+		WithGuildOnly(),
+		WithOwnerOnly(),
+		WithNSFWOnly(),
+		WithAllowedChannels("300"),
+		WithAllowedRoles("400", "401"),
+	)
+
+	command, _ := parser.GetCommand("purge")
+	if diff := deep.Equal(command, CommandDetails{
+		Name:            "purge",
+		Arguments:       []ArgumentDetails{},
+		GuildOnly:       true,
+		OwnerOnly:       true,
+		NSFWOnly:        true,
+		AllowedChannels: []string{"300"},
+		AllowedRoles:    []string{"400", "401"},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRenderCommandHelpWithRestrictions(t *testing.T) {
+	embeds := _RenderCommandHelp("!", CommandDetails{
+		Name:            "purge",
+		Arguments:       []ArgumentDetails{},
+		GuildOnly:       true,
+		AllowedChannels: []string{"300", "301"},
+		AllowedRoles:    []string{"400"},
+	}, false)
+
+	expected := "No description provided.\n\n**Usage:** `!purge`\n**Restrictions:** Servers only, Only in <#300> <#301>, Only for <@&400>"
+	if embeds[0].Description != expected {
+		t.Errorf("got incorrect description %q", embeds[0].Description)
+	}
+}
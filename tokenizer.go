@@ -0,0 +1,291 @@
+package parsley
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token represents an individual token within a message, along with the byte offsets it occupies in the message.
+type Token struct {
+	Value string
+	Start int
+	End   int
+}
+
+// Tokenizer splits the content of a message, excluding its prefix, into the tokens used as the command name and arguments.
+// Unlike DiscordTokenizer, which stops once the remainder of the message is captured by a rest argument, custom tokenizers are run on the whole message.
+type Tokenizer interface {
+	Tokenize(content string) ([]Token, error)
+}
+
+// TokenizerFunc adapts a function into a Tokenizer.
+type TokenizerFunc func(content string) ([]Token, error)
+
+// Tokenize calls the underlying function.
+func (tokenizer TokenizerFunc) Tokenize(content string) ([]Token, error) {
+	return tokenizer(content)
+}
+
+// SetTokenizer sets the tokenizer used to split messages into tokens.
+// Passing nil restores the default DiscordTokenizer.
+func (parser *Parser) SetTokenizer(tokenizer Tokenizer) {
+	if tokenizer == nil {
+		tokenizer = DiscordTokenizer{}
+	}
+	parser.tokenizer = tokenizer
+}
+
+// DiscordTokenizer is a Tokenizer built for the way users write Discord messages.
+//
+// Tokens are separated by whitespace. A token can be quoted using straight or typographic quotes, such as "a b" or “a b”,
+// allowing it to contain whitespace. Quotes are only recognized at the start of a token or directly after the first = in a token,
+// such as Name="a b", and are closed by a quote followed by whitespace or the end of the message, so apostrophes are kept as-is.
+// A double quote can also be closed by the last double quote before whitespace, such as in "a b"!.
+// A double quote without any matching quote is an error, while a single quote that is never closed is treated as an apostrophe.
+// Code blocks and inline code, such as ```code``` or `code`, are single tokens with their delimiters and any code block language removed.
+// A backslash only escapes a quote, a backtick or another backslash, and is otherwise kept as-is.
+type DiscordTokenizer struct{}
+
+// Tokenize splits the provided content into tokens, returning a *TokenizeError if a double quote or code block is never closed.
+func (tokenizer DiscordTokenizer) Tokenize(content string) ([]Token, error) {
+	tokens := make([]Token, 0)
+	for offset := 0; ; {
+		token, end, ok, err := tokenizer.next(content, offset)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return tokens, nil
+		}
+		tokens = append(tokens, token)
+		offset = end
+	}
+}
+
+// next reads the first token at or after the provided offset, returning it along with the offset following it,
+// or false if only whitespace remains.
+func (DiscordTokenizer) next(content string, offset int) (Token, int, bool, error) {
+	start := offset + len(content[offset:]) - len(strings.TrimLeftFunc(content[offset:], unicode.IsSpace))
+	if start == len(content) {
+		return Token{}, 0, false, nil
+	}
+
+	var value strings.Builder
+	// valueStart is set at positions where quotes and code are recognized.
+	valueStart := true
+	seenEquals := false
+
+	index := start
+	for index < len(content) {
+		char, size := utf8.DecodeRuneInString(content[index:])
+		if unicode.IsSpace(char) {
+			break
+		}
+
+		if valueStart && char == '`' {
+			end, err := _ReadCode(content, index, &value)
+			if err != nil {
+				return Token{}, 0, false, err
+			}
+			index = end
+			valueStart = false
+			continue
+		}
+		if valueStart && _QuoteClass(char) != 0 {
+			quoted, end, err := _ReadQuoted(content, index)
+			if err == nil {
+				value.WriteString(quoted)
+				index = end
+				valueStart = false
+				continue
+			}
+			// Unclosed single quotes are most likely apostrophes, such as in 'tis, so they are kept as-is.
+			if _QuoteClass(char) == '"' {
+				return Token{}, 0, false, err
+			}
+		}
+
+		valueStart = false
+		if char == '\\' {
+			if next, nextSize := utf8.DecodeRuneInString(content[index+size:]); _IsEscapable(next) {
+				value.WriteRune(next)
+				index += size + nextSize
+				continue
+			}
+		}
+		if char == '=' && !seenEquals {
+			seenEquals = true
+			valueStart = true
+		}
+		value.WriteRune(char)
+		index += size
+	}
+
+	return Token{value.String(), start, index}, index, true, nil
+}
+
+// _IncrementalTokenizer is implemented by tokenizers able to read a message one token at a time.
+type _IncrementalTokenizer interface {
+	next(content string, offset int) (Token, int, bool, error)
+}
+
+// _TokenStream reads the tokens of a message as they are needed, so that text which is never read, such as the remainder of the
+// message captured by a rest argument, is not tokenized and cannot cause tokenizing errors.
+// Tokenizers unable to read one token at a time are run on the whole message up front.
+type _TokenStream struct {
+	content   string
+	tokenizer _IncrementalTokenizer
+	offset    int
+	buffered  []Token
+	// consumed contains the values of the tokens that have been consumed, along with any remainder that has been captured.
+	consumed []string
+}
+
+// _NewTokenStream creates a stream reading the tokens of the provided content using the provided tokenizer.
+func _NewTokenStream(tokenizer Tokenizer, content string) (*_TokenStream, error) {
+	stream := &_TokenStream{content: content, consumed: make([]string, 0)}
+	if incremental, ok := tokenizer.(_IncrementalTokenizer); ok {
+		stream.tokenizer = incremental
+		return stream, nil
+	}
+
+	tokens, err := tokenizer.Tokenize(content)
+	if err != nil {
+		return nil, err
+	}
+	stream.buffered = tokens
+	return stream, nil
+}
+
+// peek returns the token the provided number of tokens ahead without consuming it, or false if there are not that many tokens left.
+func (stream *_TokenStream) peek(ahead int) (Token, bool, error) {
+	for len(stream.buffered) <= ahead && stream.tokenizer != nil {
+		token, end, ok, err := stream.tokenizer.next(stream.content, stream.offset)
+		if err != nil {
+			return Token{}, false, err
+		}
+		if !ok {
+			stream.tokenizer = nil
+			break
+		}
+		stream.buffered = append(stream.buffered, token)
+		stream.offset = end
+	}
+
+	if ahead >= len(stream.buffered) {
+		return Token{}, false, nil
+	}
+	return stream.buffered[ahead], true, nil
+}
+
+// advance consumes the provided number of tokens, which must have already been peeked.
+func (stream *_TokenStream) advance(count int) {
+	for _, token := range stream.buffered[:count] {
+		stream.consumed = append(stream.consumed, token.Value)
+	}
+	stream.buffered = stream.buffered[count:]
+}
+
+// remainder consumes the rest of the stream, returning the untokenized content starting at the next token.
+func (stream *_TokenStream) remainder() string {
+	start := stream.offset
+	if len(stream.buffered) != 0 {
+		start = stream.buffered[0].Start
+	}
+	remainder := strings.TrimLeftFunc(stream.content[start:], unicode.IsSpace)
+	stream.consumed = append(stream.consumed, remainder)
+	stream.buffered, stream.tokenizer = nil, nil
+	return remainder
+}
+
+// _ReadQuoted reads the quoted text starting at the provided offset, returning it along with the offset following the closing quote.
+// A quote followed by whitespace or the end of the content closes the quoted text. Double quotes can also be closed by the last matching
+// quote before whitespace, such as in "a b"!, while single quotes cannot, as they are most likely apostrophes.
+func _ReadQuoted(content string, start int) (string, int, error) {
+	open, size := utf8.DecodeRuneInString(content[start:])
+	class := _QuoteClass(open)
+
+	var value strings.Builder
+	// pendingEnd is the offset following the last matching quote seen since the previous whitespace, or -1 if there is none.
+	pendingEnd, pendingValue := -1, ""
+	for index := start + size; index < len(content); {
+		char, size := utf8.DecodeRuneInString(content[index:])
+		next, nextSize := utf8.DecodeRuneInString(content[index+size:])
+
+		switch {
+		case char == '\\' && _IsEscapable(next):
+			value.WriteRune(next)
+			index += size + nextSize
+			continue
+		case _QuoteClass(char) == class && (nextSize == 0 || unicode.IsSpace(next)):
+			return value.String(), index + size, nil
+		case _QuoteClass(char) == class && class == '"':
+			pendingEnd, pendingValue = index+size, value.String()
+		case unicode.IsSpace(char) && pendingEnd != -1:
+			return pendingValue, pendingEnd, nil
+		}
+
+		value.WriteRune(char)
+		index += size
+	}
+
+	if pendingEnd != -1 {
+		return pendingValue, pendingEnd, nil
+	}
+
+	return "", 0, &TokenizeError{Position: utf8.RuneCountInString(content[:start]) + 1, Err: ErrUnclosedQuote}
+}
+
+// _ReadCode reads the code block or inline code starting at the provided offset into value, returning the offset following its closing delimiter.
+func _ReadCode(content string, start int, value *strings.Builder) (int, error) {
+	fenceLength := len(content[start:]) - len(strings.TrimLeft(content[start:], "`"))
+	if fenceLength > 3 {
+		fenceLength = 3
+	}
+	fence := content[start : start+fenceLength]
+
+	codeStart := start + fenceLength
+	codeLength := strings.Index(content[codeStart:], fence)
+	if codeLength == -1 {
+		return 0, &TokenizeError{Position: utf8.RuneCountInString(content[:start]) + 1, Err: ErrUnclosedCodeBlock}
+	}
+
+	code := content[codeStart : codeStart+codeLength]
+	if fenceLength == 3 {
+		code = _TrimCodeBlock(code)
+	}
+	value.WriteString(code)
+
+	return codeStart + codeLength + fenceLength, nil
+}
+
+// _TrimCodeBlock removes the language from the first line of a code block, along with the newlines surrounding the code.
+func _TrimCodeBlock(code string) string {
+	if newline := strings.IndexByte(code, '\n'); newline != -1 {
+		language := code[:newline]
+		if strings.IndexFunc(language, _IsNotLanguageChar) == -1 {
+			code = code[newline+1:]
+		}
+	}
+	return strings.TrimSuffix(code, "\n")
+}
+
+// _QuoteClass returns a straight double or single quote for each kind of quote, including their typographic forms, or 0 for other characters.
+func _QuoteClass(char rune) rune {
+	switch char {
+	case '"', '“', '”', '„':
+		return '"'
+	case '\'', '‘', '’':
+		return '\''
+	}
+	return 0
+}
+
+func _IsNotLanguageChar(char rune) bool {
+	return !unicode.IsLetter(char) && !unicode.IsDigit(char) && !strings.ContainsRune("_+#.-", char)
+}
+
+func _IsEscapable(char rune) bool {
+	return char == '\\' || char == '`' || _QuoteClass(char) != 0
+}
@@ -0,0 +1,245 @@
+package parsley
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/go-test/deep"
+)
+
+func TestTokenizeWithWhitespace(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize("  a\tb\n c  ")
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"a", 2, 3},
+		{"b", 4, 5},
+		{"c", 7, 8},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithQuotes(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize(`"a b" 'c "d"' e"f g"h`)
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"a b", 0, 5},
+		{`c "d"`, 6, 13},
+		{`e"f`, 14, 17},
+		{`g"h`, 18, 21},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithSmartQuotes(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize("“a b” ‘c d’ „e f“")
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"a b", 0, 9},
+		{"c d", 10, 19},
+		{"e f", 20, 29},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithApostrophes(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize(`don't it’s 'it's fine' 'tis`)
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error: %s", err)
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"don't", 0, 5},
+		{"it’s", 6, 12},
+		{"it's fine", 13, 24},
+		{"'tis", 25, 29},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithQuotedKeywordValue(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize(`Reason="being rude" a=b=c`)
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"Reason=being rude", 0, 19},
+		{"a=b=c", 20, 25},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithEscapes(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize(`\"a "c\"d" 'e\f' C:\Users \\`)
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{`"a`, 0, 3},
+		{`c"d`, 4, 10},
+		{`e\f`, 11, 16},
+		{`C:\Users`, 17, 25},
+		{`\`, 26, 28},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithCode(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize("`a b` ``c ` d`` ```go\nfmt.Println(\"e f\")\n``` ```g h```")
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	values := make([]string, len(tokens))
+	for index, token := range tokens {
+		values[index] = token.Value
+	}
+	if diff := deep.Equal(values, []string{"a b", "c ` d", `fmt.Println("e f")`, "g h"}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithEmptyQuotes(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize(`a "" b`)
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"a", 0, 1},
+		{"", 2, 4},
+		{"b", 5, 6},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithQuoteFollowedByPunctuation(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize(`"hello world"! x "a b"?! "c"d"e f" 'g h'!`)
+	if err != nil {
+		t.Fatalf("tokenizing returned unexpected error: %s", err)
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"hello world!", 0, 14},
+		{"x", 15, 16},
+		{"a b?!", 17, 24},
+		{`c"de`, 25, 31},
+		{`f"`, 32, 34},
+		{"'g", 35, 37},
+		{"h'!", 38, 41},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestTokenizeWithUnclosedQuote(t *testing.T) {
+	_, err := DiscordTokenizer{}.Tokenize(`ä "b`)
+	if !errors.Is(err, ErrUnclosedQuote) {
+		t.Errorf("tokenizing did not return correct error")
+	}
+
+	var tokenizeErr *TokenizeError
+	if !errors.As(err, &tokenizeErr) || tokenizeErr.Position != 3 {
+		t.Errorf("tokenizing did not return correct position")
+	}
+}
+
+func TestTokenizeWithUnclosedCodeBlock(t *testing.T) {
+	_, err := DiscordTokenizer{}.Tokenize("a ```b`")
+	if !errors.Is(err, ErrUnclosedCodeBlock) {
+		t.Errorf("tokenizing did not return correct error")
+	}
+
+	var tokenizeErr *TokenizeError
+	if !errors.As(err, &tokenizeErr) || tokenizeErr.Position != 3 {
+		t.Errorf("tokenizing did not return correct position")
+	}
+}
+
+func TestTokenizeWithTrailingBackslash(t *testing.T) {
+	tokens, err := DiscordTokenizer{}.Tokenize(`a \`)
+	if err != nil {
+		t.Errorf("tokenizing returned unexpected error")
+	}
+
+	if diff := deep.Equal(tokens, []Token{
+		{"a", 0, 1},
+		{`\`, 2, 3},
+	}); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestRunCommandWithCodeBlockArgument(t *testing.T) {
+	parser := New("!")
+	called := false
+	parser.NewCommand("eval", "", func(message *discordgo.MessageCreate, args struct {
+		Code string
+	}) {
+		called = true
+		if args.Code != "x := \"a b\"\nfmt.Println(x)" {
+			t.Errorf("handler was passed incorrect code %q", args.Code)
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!eval ```go\nx := \"a b\"\nfmt.Println(x)\n```"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
+
+func TestRunCommandWithCustomTokenizer(t *testing.T) {
+	parser := New("!")
+	parser.SetTokenizer(TokenizerFunc(func(content string) ([]Token, error) {
+		tokens := make([]Token, 0)
+		start := 0
+		for index, value := range strings.Split(content, ",") {
+			if index != 0 {
+				start++
+			}
+			tokens = append(tokens, Token{value, start, start + len(value)})
+			start += len(value)
+		}
+		return tokens, nil
+	}))
+	called := false
+	parser.NewCommand("add", "", func(message *discordgo.MessageCreate, args struct {
+		A string
+		B string
+	}) {
+		called = true
+		if args.A != "a b" || args.B != "c" {
+			t.Errorf("handler was not passed correct args")
+		}
+	})
+
+	err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: "!add,a b,c"}})
+	if err != nil {
+		t.Errorf("running command returned unexpected error: %s", err)
+	}
+	if !called {
+		t.Errorf("handler was not called")
+	}
+}
@@ -0,0 +1,54 @@
+package parsley
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Usage returns a one-line synopsis of how to run the command using the provided prefix, such as "!ban <user> [reason=No reason]".
+//
+// Required arguments are shown as <name> and optional arguments as [name=default], matching the keyword syntax accepted for them.
+// Arguments consuming the rest of the message are suffixed with "...", and groups list their subcommands as <a|b>, if they have any.
+func (details CommandDetails) Usage(prefix string) string {
+	var usage strings.Builder
+	usage.WriteString(prefix)
+	usage.WriteString(details.Name)
+
+	if details.Subcommands != nil {
+		if len(details.Subcommands) == 0 {
+			return usage.String()
+		}
+		names := make([]string, 0, len(details.Subcommands))
+		for _, subcommand := range details.Subcommands {
+			names = append(names, strings.TrimPrefix(subcommand.Name, details.Name+" "))
+		}
+		usage.WriteString(" <" + strings.Join(names, "|") + ">")
+		return usage.String()
+	}
+
+	for _, arg := range details.Arguments {
+		name := arg.Name
+		if arg.Variadic || arg.Rest {
+			name += "..."
+		}
+
+		switch {
+		case arg.Required:
+			usage.WriteString(" <" + name + ">")
+		case arg.Variadic || arg.Rest:
+			usage.WriteString(" [" + name + "]")
+		default:
+			usage.WriteString(" [" + name + "=" + _QuoteIfNeeded(arg.Default) + "]")
+		}
+	}
+
+	return usage.String()
+}
+
+// _QuoteIfNeeded quotes the provided value if it would not be parsed as a single token as-is.
+func _QuoteIfNeeded(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t\n\"'\\") {
+		return strconv.Quote(value)
+	}
+	return value
+}
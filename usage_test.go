@@ -0,0 +1,96 @@
+package parsley
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestUsageWithArguments(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+		User   string
+		Reason string `default:"No reason"`
+		Days   int    `default:"0"`
+	}) {
+	})
+
+	usage, err := parser.Usage("ban")
+	if err != nil {
+		t.Errorf("got unexpected error")
+	}
+	if usage != `!ban <User> [Reason="No reason"] [Days=0]` {
+		t.Errorf("got incorrect usage %s", usage)
+	}
+}
+
+func TestUsageWithGreedyArguments(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("kick", "", func(message *discordgo.MessageCreate, args struct {
+		Users []string
+	}) {
+	})
+	parser.NewCommand("tag", "", func(message *discordgo.MessageCreate, args struct {
+		Name string
+		Text string `rest:"true" default:""`
+	}) {
+	})
+
+	usage, _ := parser.Usage("kick")
+	if usage != "!kick <Users...>" {
+		t.Errorf("got incorrect usage %s", usage)
+	}
+	usage, _ = parser.Usage("tag")
+	if usage != "!tag <Name> [Text...]" {
+		t.Errorf("got incorrect usage %s", usage)
+	}
+}
+
+func TestUsageWithGroup(t *testing.T) {
+	parser := New("!")
+	group, _ := parser.NewGroup("config", "")
+	group.NewCommand("set", "", func(message *discordgo.MessageCreate, args struct{}) {})
+	group.NewCommand("get", "", func(message *discordgo.MessageCreate, args struct{}) {})
+
+	usage, _ := parser.Usage("config")
+	if usage != "!config <get|set>" {
+		t.Errorf("got incorrect usage %s", usage)
+	}
+}
+
+func TestUsageWithEmptyGroup(t *testing.T) {
+	parser := New("!")
+	parser.NewGroup("config", "")
+
+	usage, _ := parser.Usage("config")
+	if usage != "!config" {
+		t.Errorf("got incorrect usage %s", usage)
+	}
+}
+
+func TestUsageWithUnknownCommand(t *testing.T) {
+	parser := New("!")
+
+	_, err := parser.Usage("unknown")
+	if !errors.Is(err, ErrUnknownCommand) {
+		t.Errorf("function did not return expected error")
+	}
+}
+
+func TestRunCommandArgumentErrorIncludesUsage(t *testing.T) {
+	parser := New("!")
+	parser.NewCommand("ban", "", func(message *discordgo.MessageCreate, args struct {
+		User string
+		Days int `default:"0"`
+	}) {
+	})
+
+	for _, content := range []string{"!ban", "!ban user ABC", "!ban Days=1 user"} {
+		err := parser.RunCommand(&discordgo.MessageCreate{Message: &discordgo.Message{Content: content}})
+		if err == nil || !strings.HasSuffix(err.Error(), "\nUsage: !ban <User> [Days=0]") {
+			t.Errorf("running %q did not return error including usage: %v", content, err)
+		}
+	}
+}